@@ -0,0 +1,221 @@
+package awx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	awxgo "gitlab.com/dhendel/awx-go"
+)
+
+// apiNotFoundError is returned by the raw API helpers when AWX answers 404
+type apiNotFoundError struct {
+	endpoint string
+}
+
+func (e *apiNotFoundError) Error() string {
+	return fmt.Sprintf("%s not found", e.endpoint)
+}
+
+func isAPINotFound(err error) bool {
+	_, ok := err.(*apiNotFoundError)
+	return ok
+}
+
+func checkAPIResponse(endpoint string, resp *http.Response) error {
+	if resp.StatusCode == http.StatusNotFound {
+		return &apiNotFoundError{endpoint: endpoint}
+	}
+	return awxgo.CheckResponse(resp)
+}
+
+// apiGet performs a GET on an AWX endpoint and decodes the response into result
+func (c *AWXClient) apiGet(endpoint string, result interface{}, params map[string]string) error {
+	resp, err := c.Requester.GetJSON(endpoint, result, params)
+	if err != nil {
+		return err
+	}
+	return checkAPIResponse(endpoint, resp)
+}
+
+// apiOptions performs an OPTIONS on an AWX endpoint and decodes the metadata into result
+func (c *AWXClient) apiOptions(endpoint string, result interface{}) error {
+	ar := awxgo.NewAPIRequest("OPTIONS", endpoint, nil)
+	ar.SetHeader("Content-Type", "application/json")
+	resp, err := c.Requester.Do(ar, result)
+	if err != nil {
+		return err
+	}
+	return checkAPIResponse(endpoint, resp)
+}
+
+// apiPost performs a POST on an AWX endpoint, result may be nil
+func (c *AWXClient) apiPost(endpoint string, data interface{}, result interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	resp, err := c.Requester.PostJSON(endpoint, bytes.NewReader(payload), result, nil)
+	if err != nil {
+		return err
+	}
+	return checkAPIResponse(endpoint, resp)
+}
+
+// apiPatch performs a PATCH on an AWX endpoint, result may be nil
+func (c *AWXClient) apiPatch(endpoint string, data interface{}, result interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	resp, err := c.Requester.PatchJSON(endpoint, bytes.NewReader(payload), result, nil)
+	if err != nil {
+		return err
+	}
+	return checkAPIResponse(endpoint, resp)
+}
+
+// apiDelete performs a DELETE on an AWX endpoint
+func (c *AWXClient) apiDelete(endpoint string) error {
+	var result interface{}
+	resp, err := c.Requester.Delete(endpoint, &result, nil)
+	if err != nil {
+		return err
+	}
+	return checkAPIResponse(endpoint, resp)
+}
+
+// apiListResponse is the paginated envelope returned by AWX list endpoints
+type apiListResponse struct {
+	awxgo.Pagination
+	Results []json.RawMessage `json:"results"`
+}
+
+// apiList walks every page of an AWX list endpoint and calls each for every result
+func (c *AWXClient) apiList(endpoint string, params map[string]string, each func(json.RawMessage) error) error {
+	query := map[string]string{"page_size": "200"}
+	for k, v := range params {
+		query[k] = v
+	}
+	for page := 1; ; page++ {
+		query["page"] = strconv.Itoa(page)
+		result := new(apiListResponse)
+		if err := c.apiGet(endpoint, result, query); err != nil {
+			return err
+		}
+		for _, r := range result.Results {
+			if err := each(r); err != nil {
+				return err
+			}
+		}
+		if result.Next == nil {
+			return nil
+		}
+	}
+}
+
+// apiListIDs returns the IDs of every object listed by an AWX endpoint
+func (c *AWXClient) apiListIDs(endpoint string, params map[string]string) ([]int, error) {
+	var ids []int
+	err := c.apiList(endpoint, params, func(raw json.RawMessage) error {
+		obj := new(awxgo.Result)
+		if err := json.Unmarshal(raw, obj); err != nil {
+			return err
+		}
+		ids = append(ids, obj.ID)
+		return nil
+	})
+	return ids, err
+}
+
+// apiListNames returns the sorted names of every object listed by an AWX endpoint
+func (c *AWXClient) apiListNames(endpoint string, params map[string]string) ([]string, error) {
+	var names []string
+	err := c.apiList(endpoint, params, func(raw json.RawMessage) error {
+		obj := new(awxgo.Result)
+		if err := json.Unmarshal(raw, obj); err != nil {
+			return err
+		}
+		names = append(names, obj.Name)
+		return nil
+	})
+	sort.Strings(names)
+	return names, err
+}
+
+// apiAssociate links the object id to the related list endpoint
+func (c *AWXClient) apiAssociate(endpoint string, id int) error {
+	return c.apiPost(endpoint, map[string]interface{}{"id": id}, nil)
+}
+
+// apiDisassociate unlinks the object id from the related list endpoint, an
+// object which is already gone is not an error
+func (c *AWXClient) apiDisassociate(endpoint string, id int) error {
+	err := c.apiPost(endpoint, map[string]interface{}{"id": id, "disassociate": true}, nil)
+	if err != nil && !isAPINotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// apiUpdateAssociations applies the difference between two sets of ids to a related list endpoint
+func (c *AWXClient) apiUpdateAssociations(endpoint string, old, new *schema.Set) error {
+	for _, v := range old.Difference(new).List() {
+		if err := c.apiDisassociate(endpoint, v.(int)); err != nil {
+			return fmt.Errorf("Failed to disassociate %d from %s: %s", v.(int), endpoint, err)
+		}
+	}
+	for _, v := range new.Difference(old).List() {
+		if err := c.apiAssociate(endpoint, v.(int)); err != nil {
+			return fmt.Errorf("Failed to associate %d with %s: %s", v.(int), endpoint, err)
+		}
+	}
+	return nil
+}
+
+// apiReplaceOrderedAssociations sets an ordered related list endpoint to new.
+// AWX keeps these lists in association order, so the entries after the
+// common prefix of old and new are removed and added again in order.
+func (c *AWXClient) apiReplaceOrderedAssociations(endpoint string, old, new []interface{}) error {
+	remove, add := orderedAssociationChanges(old, new)
+	for _, id := range remove {
+		if err := c.apiDisassociate(endpoint, id); err != nil {
+			return fmt.Errorf("Failed to disassociate %d from %s: %s", id, endpoint, err)
+		}
+	}
+	for _, id := range add {
+		if err := c.apiAssociate(endpoint, id); err != nil {
+			return fmt.Errorf("Failed to associate %d with %s: %s", id, endpoint, err)
+		}
+	}
+	return nil
+}
+
+// orderedAssociationChanges returns the ids to disassociate and associate to
+// turn the ordered list old into new, keeping their common prefix
+func orderedAssociationChanges(old, new []interface{}) (remove, add []int) {
+	prefix := 0
+	for prefix < len(old) && prefix < len(new) && old[prefix].(int) == new[prefix].(int) {
+		prefix++
+	}
+	for _, v := range old[prefix:] {
+		remove = append(remove, v.(int))
+	}
+	for _, v := range new[prefix:] {
+		add = append(add, v.(int))
+	}
+	return remove, add
+}
+
+// apiIsAssociated reports whether the object id is listed by the related list endpoint
+func (c *AWXClient) apiIsAssociated(endpoint string, id int) (bool, error) {
+	ids, err := c.apiListIDs(endpoint, map[string]string{"id": strconv.Itoa(id)})
+	if err != nil {
+		return false, err
+	}
+	return len(ids) > 0, nil
+}
@@ -0,0 +1,25 @@
+package awx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOrderedAssociationChanges(t *testing.T) {
+	cases := []struct {
+		old, new    []interface{}
+		remove, add []int
+	}{
+		{[]interface{}{1, 2}, []interface{}{1, 2, 3}, nil, []int{3}},
+		{[]interface{}{1, 2, 3}, []interface{}{1, 3}, []int{2, 3}, []int{3}},
+		{[]interface{}{1, 2}, []interface{}{2, 1}, []int{1, 2}, []int{2, 1}},
+		{[]interface{}{1, 2}, []interface{}{1, 2}, nil, nil},
+		{nil, []interface{}{4}, nil, []int{4}},
+	}
+	for _, c := range cases {
+		remove, add := orderedAssociationChanges(c.old, c.new)
+		if !reflect.DeepEqual(remove, c.remove) || !reflect.DeepEqual(add, c.add) {
+			t.Errorf("orderedAssociationChanges(%v, %v) = %v, %v, want %v, %v", c.old, c.new, remove, add, c.remove, c.add)
+		}
+	}
+}
@@ -15,8 +15,15 @@ type Config struct {
 	Sslverify bool
 }
 
+// AWXClient wraps the awx-go services with a raw requester used for the
+// API endpoints awx-go doesn't implement
+type AWXClient struct {
+	*awxgo.AWX
+	Requester *awxgo.Requester
+}
+
 // Client for Tower/AWX API v2
-func (c *Config) Client() *awxgo.AWX {
+func (c *Config) Client() *AWXClient {
 	tr := &http.Transport{
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: c.Sslverify},
 	}
@@ -25,5 +32,12 @@ func (c *Config) Client() *awxgo.AWX {
 
 	awx := awxgo.NewAWX(c.Endpoint, c.Username, c.Password, client)
 
-	return awx
+	return &AWXClient{
+		AWX: awx,
+		Requester: &awxgo.Requester{
+			Base:      c.Endpoint,
+			BasicAuth: &awxgo.BasicAuth{Username: c.Username, Password: c.Password},
+			Client:    client,
+		},
+	}
 }
@@ -12,3 +12,20 @@ func AtoipOr(s string, defaultValue *int) *int {
 	}
 	return &n
 }
+
+// intsToInterfaces converts a list of ints for use in schema sets and lists
+func intsToInterfaces(ints []int) []interface{} {
+	result := make([]interface{}, len(ints))
+	for i, v := range ints {
+		result[i] = v
+	}
+	return result
+}
+
+// stringOrNil returns nil for an empty string, so optional string fields are cleared
+func stringOrNil(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,12 @@
+package awx
+
+import "testing"
+
+func TestStringOrNil(t *testing.T) {
+	if v := stringOrNil(""); v != nil {
+		t.Errorf("stringOrNil(\"\") = %v, want nil", v)
+	}
+	if v := stringOrNil("/var/lib/awx/venv/ansible/"); v != "/var/lib/awx/venv/ansible/" {
+		t.Errorf("stringOrNil() = %v, want the path", v)
+	}
+}
@@ -1,47 +1,95 @@
 package awx
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"gitlab.com/dhendel/awx-go"
-	"strconv"
 )
 
 func dataSourceHost() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceInventoryRead,
+		Read: dataSourceHostRead,
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of this inventory",
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of this host",
+			},
+			"inventory_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Id of the inventory the host belongs to",
 			},
 			"id": &schema.Schema{
 				Type:        schema.TypeInt,
+				Optional:    true,
 				Computed:    true,
-				Description: "Id of the ansible inventory",
+				Description: "Id of the ansible host, looks up that exact host when set",
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"variables": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"instance_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"group_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Computed:    true,
+				Description: "Ids of the groups the host is a direct member of",
 			},
 		},
 	}
 }
 
 func dataSourceHostRead(d *schema.ResourceData, meta interface{}) error {
-	awx := meta.(*awx.AWX)
+	awx := meta.(*AWXClient)
 	awxService := awx.HostService
-	_, res, err := awxService.ListHosts(map[string]string{
-		"name": d.Get("name").(string)})
+	params, err := dataSourceLookupParams(d, map[string]string{
+		"inventory_id": "inventory",
+	})
 	if err != nil {
 		return err
 	}
-	if len(res.Results) == 0 {
-		return nil
+	_, res, err := awxService.ListHosts(params)
+	if err != nil {
+		return err
+	}
+	if err := checkLookupMatches("host", params, len(res.Results), func(i int) int { return res.Results[i].ID }); err != nil {
+		return err
+	}
+	r := res.Results[0]
+	groups, err := getHostGroupIDs(awx, r.ID)
+	if err != nil {
+		return fmt.Errorf("Failed to list groups of host %d: %s", r.ID, err)
 	}
-	d.SetId(strconv.Itoa(res.Results[0].ID))
-	d = setHostSourceData(d, res.Results[0])
+	d.SetId(strconv.Itoa(r.ID))
+	d = setHostSourceData(d, r)
+	d.Set("group_ids", groups)
 	return nil
 }
 
 func setHostSourceData(d *schema.ResourceData, r *awx.Host) *schema.ResourceData {
 	d.Set("name", r.Name)
 	d.Set("id", r.ID)
+	d.Set("inventory_id", r.Inventory)
+	d.Set("description", r.Description)
+	d.Set("variables", normalizeJSONYaml(r.Variables))
+	d.Set("enabled", r.Enabled)
+	d.Set("instance_id", r.InstanceID)
 	return d
 }
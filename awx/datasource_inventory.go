@@ -1,9 +1,14 @@
 package awx
 
 import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"gitlab.com/dhendel/awx-go"
-	"strconv"
+	"gopkg.in/yaml.v2"
 )
 
 func dataSourceInventory() *schema.Resource {
@@ -12,36 +17,228 @@ func dataSourceInventory() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				Description: "Name of this inventory",
 			},
+			"organization_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Id of the organization owning the inventory, names are only unique per organization",
+			},
 			"id": &schema.Schema{
 				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Id of the ansible inventory, looks up that exact inventory when set",
+			},
+			"variables": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"kind": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"host_filter": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"total_hosts": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"hosts_with_active_failures": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"include_members": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Populate group_names and host_names",
+			},
+			"group_names": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"host_names": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"include_script": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Populate script and inventory_yaml from the inventory script endpoint",
+			},
+			"script": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Inventory rendered by AWX as an Ansible JSON inventory script output, hostvars included",
+			},
+			"inventory_yaml": &schema.Schema{
+				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "Id of the ansible inventory",
+				Description: "Static Ansible YAML inventory usable with ansible -i",
 			},
 		},
 	}
 }
 
 func dataSourceInventoryRead(d *schema.ResourceData, meta interface{}) error {
-	awx := meta.(*awx.AWX)
+	awx := meta.(*AWXClient)
 	awxService := awx.InventoriesService
-	_, res, err := awxService.ListInventories(map[string]string{
-		"name": d.Get("name").(string)})
+	params, err := dataSourceLookupParams(d, map[string]string{
+		"organization_id": "organization",
+	})
 	if err != nil {
 		return err
 	}
-	if len(res.Results) == 0 {
-		return nil
+	_, res, err := awxService.ListInventories(params)
+	if err != nil {
+		return err
+	}
+	if err := checkLookupMatches("inventory", params, len(res.Results), func(i int) int { return res.Results[i].ID }); err != nil {
+		return err
+	}
+	r := res.Results[0]
+	d.SetId(strconv.Itoa(r.ID))
+	d = setInventoryDataSource(d, r)
+
+	if d.Get("include_members").(bool) {
+		groups, err := awx.apiListNames(fmt.Sprintf("/api/v2/inventories/%d/groups/", r.ID), nil)
+		if err != nil {
+			return err
+		}
+		hosts, err := awx.apiListNames(fmt.Sprintf("/api/v2/inventories/%d/hosts/", r.ID), nil)
+		if err != nil {
+			return err
+		}
+		d.Set("group_names", groups)
+		d.Set("host_names", hosts)
+	}
+
+	if d.Get("include_script").(bool) {
+		script := make(map[string]interface{})
+		err := awx.apiGet(fmt.Sprintf("/api/v2/inventories/%d/script/", r.ID), &script, map[string]string{
+			"hostvars": "1",
+			"all":      "1",
+		})
+		if err != nil {
+			return err
+		}
+		b, err := json.Marshal(script)
+		if err != nil {
+			return err
+		}
+		y, err := yaml.Marshal(inventoryScriptToYaml(script))
+		if err != nil {
+			return err
+		}
+		d.Set("script", string(b))
+		d.Set("inventory_yaml", string(y))
 	}
-	d.SetId(strconv.Itoa(res.Results[0].ID))
-	d = setInventoryDataSource(d, res.Results[0])
 	return nil
 }
 
 func setInventoryDataSource(d *schema.ResourceData, r *awx.Inventory) *schema.ResourceData {
 	d.Set("name", r.Name)
 	d.Set("id", r.ID)
+	d.Set("organization_id", r.Organization)
+	d.Set("variables", normalizeJSONYaml(r.Variables))
+	d.Set("kind", r.Kind)
+	if hostFilter, ok := r.HostFilter.(string); ok {
+		d.Set("host_filter", hostFilter)
+	} else {
+		d.Set("host_filter", "")
+	}
+	d.Set("total_hosts", r.TotalHosts)
+	d.Set("hosts_with_active_failures", r.HostsWithActiveFailures)
 	return d
 }
+
+// inventoryScriptToYaml converts the output of the inventory script endpoint
+// into the layout of the Ansible YAML inventory plugin. Host variables are set
+// once under all, groups only reference their hosts and children.
+func inventoryScriptToYaml(script map[string]interface{}) map[string]interface{} {
+	hosts := make(map[string]interface{})
+	if meta, ok := script["_meta"].(map[string]interface{}); ok {
+		if hostvars, ok := meta["hostvars"].(map[string]interface{}); ok {
+			for name, vars := range hostvars {
+				hosts[name] = emptyAsNil(vars)
+			}
+		}
+	}
+	all := map[string]interface{}{"hosts": hosts}
+	children := make(map[string]interface{})
+	for name, raw := range script {
+		if name == "_meta" {
+			continue
+		}
+		g, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name == "all" {
+			if vars := emptyAsNil(g["vars"]); vars != nil {
+				all["vars"] = vars
+			}
+			for _, h := range toStrings(g["hosts"]) {
+				if _, ok := hosts[h]; !ok {
+					hosts[h] = nil
+				}
+			}
+			continue
+		}
+		group := make(map[string]interface{})
+		if vars := emptyAsNil(g["vars"]); vars != nil {
+			group["vars"] = vars
+		}
+		if names := toStrings(g["hosts"]); len(names) > 0 {
+			members := make(map[string]interface{})
+			for _, h := range names {
+				members[h] = nil
+				if _, ok := hosts[h]; !ok {
+					hosts[h] = nil
+				}
+			}
+			group["hosts"] = members
+		}
+		if names := toStrings(g["children"]); len(names) > 0 {
+			sub := make(map[string]interface{})
+			for _, c := range names {
+				sub[c] = nil
+			}
+			group["children"] = sub
+		}
+		children[name] = emptyAsNil(group)
+	}
+	if len(children) > 0 {
+		all["children"] = children
+	}
+	return map[string]interface{}{"all": all}
+}
+
+func emptyAsNil(v interface{}) interface{} {
+	if m, ok := v.(map[string]interface{}); ok && len(m) == 0 {
+		return nil
+	}
+	return v
+}
+
+func toStrings(v interface{}) []string {
+	var result []string
+	if list, ok := v.([]interface{}); ok {
+		for _, i := range list {
+			if s, ok := i.(string); ok {
+				result = append(result, s)
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -1,9 +1,11 @@
 package awx
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"gitlab.com/dhendel/awx-go"
-	"strconv"
 )
 
 func dataSourceInventoryGroup() *schema.Resource {
@@ -12,37 +14,74 @@ func dataSourceInventoryGroup() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				Description: "Name of this group",
 			},
 			"id": &schema.Schema{
 				Type:        schema.TypeInt,
+				Optional:    true,
 				Computed:    true,
-				Description: "Id of the ansible inventory group",
+				Description: "Id of the ansible inventory group, looks up that exact group when set",
 			},
 			"inventory_id": &schema.Schema{
 				Type:        schema.TypeInt,
+				Optional:    true,
 				Computed:    true,
 				Description: "Id of the ansible inventory this group belongs to",
 			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"variables": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"child_group_ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Computed: true,
+			},
+			"parent_group_ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Computed: true,
+			},
 		},
 	}
 }
 
 func dataSourceInventoryGroupRead(d *schema.ResourceData, meta interface{}) error {
-	awx := meta.(*awx.AWX)
+	awx := meta.(*AWXClient)
 	awxService := awx.GroupService
-	_, res, err := awxService.ListGroups(map[string]string{
-		"name": d.Get("name").(string)})
+	params, err := dataSourceLookupParams(d, map[string]string{
+		"inventory_id": "inventory",
+	})
+	if err != nil {
+		return err
+	}
+	_, res, err := awxService.ListGroups(params)
 	if err != nil {
 		return err
 	}
-	if len(res.Results) == 0 {
-		return nil
+	if err := checkLookupMatches("group", params, len(res.Results), func(i int) int { return res.Results[i].ID }); err != nil {
+		return err
 	}
 
-	d.SetId(strconv.Itoa(res.Results[0].ID))
-	d = setInventoryGroupSourceData(d, res.Results[0])
+	r := res.Results[0]
+	children, err := awx.apiListIDs(fmt.Sprintf("/api/v2/groups/%d/children/", r.ID), nil)
+	if err != nil {
+		return err
+	}
+	parents, err := awx.apiListIDs("/api/v2/groups/", map[string]string{"children": strconv.Itoa(r.ID)})
+	if err != nil {
+		return err
+	}
+	d.SetId(strconv.Itoa(r.ID))
+	d = setInventoryGroupSourceData(d, r)
+	d.Set("child_group_ids", children)
+	d.Set("parent_group_ids", parents)
 	return nil
 }
 
@@ -50,5 +89,7 @@ func setInventoryGroupSourceData(d *schema.ResourceData, r *awx.Group) *schema.R
 	d.Set("name", r.Name)
 	d.Set("id", r.ID)
 	d.Set("inventory_id", r.Inventory)
+	d.Set("description", r.Description)
+	d.Set("variables", normalizeJSONYaml(r.Variables))
 	return d
 }
@@ -0,0 +1,52 @@
+package awx
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestInventoryScriptToYaml(t *testing.T) {
+	script := make(map[string]interface{})
+	err := json.Unmarshal([]byte(`{
+		"all": {"vars": {"env": "prod"}, "hosts": ["lonely"]},
+		"k8s": {"hosts": ["node1"], "children": ["etcd"], "vars": {}},
+		"etcd": {"hosts": ["node1", "node2"]},
+		"_meta": {"hostvars": {"node1": {"ansible_host": "10.0.0.1"}, "node2": {}}}
+	}`), &script)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got, want interface{}
+	b, err := yaml.Marshal(inventoryScriptToYaml(script))
+	if err != nil {
+		t.Fatal(err)
+	}
+	yaml.Unmarshal(b, &got)
+	yaml.Unmarshal([]byte(`
+all:
+  vars:
+    env: prod
+  hosts:
+    lonely:
+    node1:
+      ansible_host: 10.0.0.1
+    node2:
+  children:
+    k8s:
+      hosts:
+        node1:
+      children:
+        etcd:
+    etcd:
+      hosts:
+        node1:
+        node2:
+`), &want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected inventory:\n%s", b)
+	}
+}
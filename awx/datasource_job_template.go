@@ -1,9 +1,10 @@
 package awx
 
 import (
+	"strconv"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"gitlab.com/dhendel/awx-go"
-	"strconv"
 )
 
 func dataSourceJobTemplate() *schema.Resource {
@@ -12,13 +13,26 @@ func dataSourceJobTemplate() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				Description: "Name of this job template",
 			},
+			"organization_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Id of the organization of the job template project",
+			},
+			"project_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Id of the project of the job template",
+			},
 			"id": &schema.Schema{
 				Type:        schema.TypeInt,
+				Optional:    true,
 				Computed:    true,
-				Description: "Id of the ansible job template",
+				Description: "Id of the ansible job template, looks up that exact job template when set",
 			},
 			"prompt_inventory": &schema.Schema{
 				Type:        schema.TypeBool,
@@ -30,15 +44,21 @@ func dataSourceJobTemplate() *schema.Resource {
 }
 
 func dataSourceJobTemplateRead(d *schema.ResourceData, meta interface{}) error {
-	awx := meta.(*awx.AWX)
+	awx := meta.(*AWXClient)
 	awxService := awx.JobTemplateService
-	_, res, err := awxService.ListJobTemplates(map[string]string{
-		"name": d.Get("name").(string)})
+	params, err := dataSourceLookupParams(d, map[string]string{
+		"organization_id": "project__organization",
+		"project_id":      "project",
+	})
+	if err != nil {
+		return err
+	}
+	_, res, err := awxService.ListJobTemplates(params)
 	if err != nil {
 		return err
 	}
-	if len(res.Results) == 0 {
-		return nil
+	if err := checkLookupMatches("job template", params, len(res.Results), func(i int) int { return res.Results[i].ID }); err != nil {
+		return err
 	}
 	d.SetId(strconv.Itoa(res.Results[0].ID))
 	d = setJobTemplateDataSourceData(d, res.Results[0])
@@ -48,6 +68,7 @@ func dataSourceJobTemplateRead(d *schema.ResourceData, meta interface{}) error {
 func setJobTemplateDataSourceData(d *schema.ResourceData, r *awx.JobTemplate) *schema.ResourceData {
 	d.Set("name", r.Name)
 	d.Set("id", r.ID)
+	d.Set("project_id", r.Project)
 	d.Set("prompt_inventory", r.AskInventoryOnLaunch)
 	return d
 }
@@ -0,0 +1,218 @@
+package awx
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// listAttribute maps an attribute of a listed object to the AWX api field it is read from
+type listAttribute struct {
+	field string
+	kind  schema.ValueType
+}
+
+// dataSourceList builds a plural data source listing every object of endpoint
+// matching the AWX query filters. Each object is returned with id, name and the
+// given key attributes, ordered by id.
+func dataSourceList(endpoint, key, nameField string, attributes map[string]listAttribute) *schema.Resource {
+	elem := map[string]*schema.Schema{
+		"id": &schema.Schema{
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"name": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+	for attr, a := range attributes {
+		elem[attr] = &schema.Schema{
+			Type:     a.kind,
+			Computed: true,
+		}
+	}
+	return &schema.Resource{
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			return dataSourceListRead(d, meta, endpoint, key, nameField, attributes)
+		},
+		Schema: map[string]*schema.Schema{
+			"filters": &schema.Schema{
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "AWX query filters, e.g. name__startswith or organization",
+			},
+			"ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Computed: true,
+			},
+			"names": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			key: &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     &schema.Resource{Schema: elem},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceListRead(d *schema.ResourceData, meta interface{}, endpoint, key, nameField string, attributes map[string]listAttribute) error {
+	awx := meta.(*AWXClient)
+	params := make(map[string]string)
+	for k, v := range d.Get("filters").(map[string]interface{}) {
+		params[k] = v.(string)
+	}
+
+	var objects []map[string]interface{}
+	err := awx.apiList(endpoint, params, func(raw json.RawMessage) error {
+		obj := make(map[string]interface{})
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return err
+		}
+		objects = append(objects, listObject(obj, nameField, attributes))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i]["id"].(int) < objects[j]["id"].(int)
+	})
+
+	ids := make([]int, 0, len(objects))
+	names := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		ids = append(ids, obj["id"].(int))
+		names = append(names, obj["name"].(string))
+	}
+	d.SetId(listDataSourceID(endpoint, params))
+	d.Set("ids", ids)
+	d.Set("names", names)
+	if err := d.Set(key, objects); err != nil {
+		return fmt.Errorf("Failed to set %s: %s", key, err)
+	}
+	return nil
+}
+
+// listObject flattens an api object into the attributes of the list element,
+// null fields like a job template without inventory become zero values
+func listObject(obj map[string]interface{}, nameField string, attributes map[string]listAttribute) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":   listValue(obj["id"], schema.TypeInt),
+		"name": listValue(obj[nameField], schema.TypeString),
+	}
+	for attr, a := range attributes {
+		result[attr] = listValue(obj[a.field], a.kind)
+	}
+	return result
+}
+
+func listValue(v interface{}, kind schema.ValueType) interface{} {
+	switch kind {
+	case schema.TypeInt:
+		if f, ok := v.(float64); ok {
+			return int(f)
+		}
+		return 0
+	case schema.TypeBool:
+		b, _ := v.(bool)
+		return b
+	default:
+		switch s := v.(type) {
+		case string:
+			return s
+		case nil:
+			return ""
+		default:
+			return fmt.Sprintf("%v", s)
+		}
+	}
+}
+
+// listDataSourceID derives a stable id from the endpoint and the filters
+func listDataSourceID(endpoint string, params map[string]string) string {
+	var filters []string
+	for k, v := range params {
+		filters = append(filters, k+"="+v)
+	}
+	sort.Strings(filters)
+	return strconv.Itoa(hashcode.String(endpoint + "?" + strings.Join(filters, "&")))
+}
+
+func dataSourceJobTemplates() *schema.Resource {
+	return dataSourceList("/api/v2/job_templates/", "job_templates", "name", map[string]listAttribute{
+		"description":  {"description", schema.TypeString},
+		"job_type":     {"job_type", schema.TypeString},
+		"project_id":   {"project", schema.TypeInt},
+		"inventory_id": {"inventory", schema.TypeInt},
+		"playbook":     {"playbook", schema.TypeString},
+	})
+}
+
+func dataSourceProjects() *schema.Resource {
+	return dataSourceList("/api/v2/projects/", "projects", "name", map[string]listAttribute{
+		"description":     {"description", schema.TypeString},
+		"organization_id": {"organization", schema.TypeInt},
+		"scm_type":        {"scm_type", schema.TypeString},
+		"scm_url":         {"scm_url", schema.TypeString},
+		"scm_branch":      {"scm_branch", schema.TypeString},
+		"scm_revision":    {"scm_revision", schema.TypeString},
+		"status":          {"status", schema.TypeString},
+	})
+}
+
+func dataSourceInventories() *schema.Resource {
+	return dataSourceList("/api/v2/inventories/", "inventories", "name", map[string]listAttribute{
+		"description":     {"description", schema.TypeString},
+		"organization_id": {"organization", schema.TypeInt},
+		"kind":            {"kind", schema.TypeString},
+		"host_filter":     {"host_filter", schema.TypeString},
+		"total_hosts":     {"total_hosts", schema.TypeInt},
+	})
+}
+
+func dataSourceHosts() *schema.Resource {
+	return dataSourceList("/api/v2/hosts/", "hosts", "name", map[string]listAttribute{
+		"description":  {"description", schema.TypeString},
+		"inventory_id": {"inventory", schema.TypeInt},
+		"enabled":      {"enabled", schema.TypeBool},
+		"instance_id":  {"instance_id", schema.TypeString},
+	})
+}
+
+func dataSourceCredentials() *schema.Resource {
+	return dataSourceList("/api/v2/credentials/", "credentials", "name", map[string]listAttribute{
+		"description":        {"description", schema.TypeString},
+		"organization_id":    {"organization", schema.TypeInt},
+		"credential_type_id": {"credential_type", schema.TypeInt},
+		"kind":               {"kind", schema.TypeString},
+	})
+}
+
+func dataSourceUsers() *schema.Resource {
+	return dataSourceList("/api/v2/users/", "users", "username", map[string]listAttribute{
+		"username":     {"username", schema.TypeString},
+		"email":        {"email", schema.TypeString},
+		"first_name":   {"first_name", schema.TypeString},
+		"last_name":    {"last_name", schema.TypeString},
+		"is_superuser": {"is_superuser", schema.TypeBool},
+	})
+}
+
+func dataSourceTeams() *schema.Resource {
+	return dataSourceList("/api/v2/teams/", "teams", "name", map[string]listAttribute{
+		"description":     {"description", schema.TypeString},
+		"organization_id": {"organization", schema.TypeInt},
+	})
+}
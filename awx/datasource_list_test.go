@@ -0,0 +1,46 @@
+package awx
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestListObject(t *testing.T) {
+	obj := make(map[string]interface{})
+	raw := `{"id": 12, "name": "deploy", "project": 4, "inventory": null, "ask_inventory_on_launch": true}`
+	if err := json.Unmarshal([]byte(raw), &obj); err != nil {
+		t.Fatal(err)
+	}
+	got := listObject(obj, "name", map[string]listAttribute{
+		"project_id":       {"project", schema.TypeInt},
+		"inventory_id":     {"inventory", schema.TypeInt},
+		"prompt_inventory": {"ask_inventory_on_launch", schema.TypeBool},
+		"playbook":         {"playbook", schema.TypeString},
+	})
+	want := map[string]interface{}{
+		"id":               12,
+		"name":             "deploy",
+		"project_id":       4,
+		"inventory_id":     0,
+		"prompt_inventory": true,
+		"playbook":         "",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("listObject() = %v, want %v", got, want)
+	}
+}
+
+func TestListDataSourceID(t *testing.T) {
+	a := listDataSourceID("/api/v2/hosts/", map[string]string{"inventory": "3", "name__startswith": "web"})
+	b := listDataSourceID("/api/v2/hosts/", map[string]string{"name__startswith": "web", "inventory": "3"})
+	c := listDataSourceID("/api/v2/hosts/", map[string]string{"inventory": "4", "name__startswith": "web"})
+	if a != b {
+		t.Errorf("id depends on the filter order: %s != %s", a, b)
+	}
+	if a == c {
+		t.Errorf("different filters share the id %s", a)
+	}
+}
@@ -1,9 +1,10 @@
 package awx
 
 import (
+	"strconv"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"gitlab.com/dhendel/awx-go"
-	"strconv"
 )
 
 func dataSourceProjectObject() *schema.Resource {
@@ -12,28 +13,56 @@ func dataSourceProjectObject() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				Description: "Name of this project",
 			},
+			"organization_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Id of the organization owning the project",
+			},
 			"id": &schema.Schema{
 				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Id of the ansible project, looks up that exact project when set",
+			},
+			"scm_revision": &schema.Schema{
+				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "Id of the ansible project",
+				Description: "The SCM revision of the last successful project update",
+			},
+			"last_update_status": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the last project update",
+			},
+			"last_updated": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Finish time of the last project update, RFC3339 formatted",
 			},
 		},
 	}
 }
 
 func dataSourceProjectObjectRead(d *schema.ResourceData, meta interface{}) error {
-	awx := meta.(*awx.AWX)
+	awx := meta.(*AWXClient)
 	awxService := awx.ProjectService
-	_, res, err := awxService.ListProjects(map[string]string{
-		"name": d.Get("name").(string)})
+	params, err := dataSourceLookupParams(d, map[string]string{
+		"organization_id": "organization",
+	})
 	if err != nil {
 		return err
 	}
-	if len(res.Results) == 0 {
-		return nil
+	_, res, err := awxService.ListProjects(params)
+	if err != nil {
+		return err
+	}
+	if err := checkLookupMatches("project", params, len(res.Results), func(i int) int { return res.Results[i].ID }); err != nil {
+		return err
 	}
 	d.SetId(strconv.Itoa(res.Results[0].ID))
 	d = setProjectDataSourceData(d, res.Results[0])
@@ -43,5 +72,9 @@ func dataSourceProjectObjectRead(d *schema.ResourceData, meta interface{}) error
 func setProjectDataSourceData(d *schema.ResourceData, r *awx.Project) *schema.ResourceData {
 	d.Set("name", r.Name)
 	d.Set("id", r.ID)
+	d.Set("organization_id", r.Organization)
+	d.Set("scm_revision", r.ScmRevision)
+	d.Set("last_update_status", projectLastUpdateStatus(r))
+	d.Set("last_updated", projectLastUpdated(r))
 	return d
 }
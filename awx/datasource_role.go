@@ -0,0 +1,79 @@
+package awx
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func dataSourceRole() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRoleRead,
+		Schema: map[string]*schema.Schema{
+			"resource_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(roleResourceTypes(), false),
+				Description:  "Type of the object the roles belong to",
+			},
+			"resource_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Id of the object, looks up that exact object when set",
+			},
+			"resource_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the object",
+			},
+			"organization_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Id of the organization scoping the resource_name lookup",
+			},
+			"role": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the role to return in role_id, e.g. admin or job template admin",
+			},
+			"role_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Id of the role named by role",
+			},
+			"roles": &schema.Schema{
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Computed:    true,
+				Description: "Ids of every role of the object keyed by role name",
+			},
+		},
+	}
+}
+
+func dataSourceRoleRead(d *schema.ResourceData, meta interface{}) error {
+	awx := meta.(*AWXClient)
+	if d.Get("resource_id").(int) == 0 && d.Get("resource_name").(string) == "" {
+		return fmt.Errorf("One of resource_id or resource_name must be set")
+	}
+	resourceType := d.Get("resource_type").(string)
+	roles, err := getObjectRoles(awx, resourceType, roleResourceParams(d))
+	if err != nil {
+		return err
+	}
+	if name, ok := d.GetOk("role"); ok {
+		id, err := roles.roleID(name.(string))
+		if err != nil {
+			return err
+		}
+		d.Set("role_id", id)
+	}
+	d.SetId(fmt.Sprintf("%s:%d", resourceType, roles.resourceID))
+	d.Set("resource_id", roles.resourceID)
+	d.Set("resource_name", roles.resourceName)
+	d.Set("roles", roles.roles)
+	return nil
+}
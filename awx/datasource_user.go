@@ -0,0 +1,118 @@
+package awx
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	awxgo "gitlab.com/dhendel/awx-go"
+)
+
+func dataSourceUser() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceUserRead,
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Id of the user, looks up that exact user when set",
+			},
+			"username": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Username of the user",
+			},
+			"email": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Email address of the user",
+			},
+			"first_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"is_superuser": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"is_system_auditor": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"auth_source": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"team_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Computed:    true,
+				Description: "Ids of the teams the user is member of",
+			},
+			"organization_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Computed:    true,
+				Description: "Ids of the organizations the user is member or administrator of",
+			},
+		},
+	}
+}
+
+func dataSourceUserRead(d *schema.ResourceData, meta interface{}) error {
+	awx := meta.(*AWXClient)
+	awxService := awx.UserService
+	params := make(map[string]string)
+	if id, ok := d.GetOk("id"); ok {
+		params["id"] = strconv.Itoa(id.(int))
+	} else {
+		for _, attr := range []string{"username", "email"} {
+			if v, ok := d.GetOk(attr); ok {
+				params[attr] = v.(string)
+			}
+		}
+	}
+	if len(params) == 0 {
+		return fmt.Errorf("One of id, username or email must be set")
+	}
+	_, res, err := awxService.ListUsers(params)
+	if err != nil {
+		return err
+	}
+	if err := checkLookupMatches("user", params, len(res.Results), func(i int) int { return res.Results[i].ID }); err != nil {
+		return err
+	}
+	r := res.Results[0]
+	teams, err := awx.apiListIDs(fmt.Sprintf("/api/v2/users/%d/teams/", r.ID), nil)
+	if err != nil {
+		return err
+	}
+	orgs, err := awx.apiListIDs(fmt.Sprintf("/api/v2/users/%d/organizations/", r.ID), nil)
+	if err != nil {
+		return err
+	}
+	d.SetId(strconv.Itoa(r.ID))
+	d = setUserDataSourceData(d, r)
+	d.Set("team_ids", teams)
+	d.Set("organization_ids", orgs)
+	return nil
+}
+
+func setUserDataSourceData(d *schema.ResourceData, r *awxgo.User) *schema.ResourceData {
+	d.Set("id", r.ID)
+	d.Set("username", r.Username)
+	d.Set("email", r.Email)
+	d.Set("first_name", r.FirstName)
+	d.Set("last_name", r.LastName)
+	d.Set("is_superuser", r.IsSuperUser)
+	d.Set("is_system_auditor", r.IsSystemAuditor)
+	d.Set("auth_source", userAuthSource(r))
+	return d
+}
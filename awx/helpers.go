@@ -3,9 +3,12 @@ package awx
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
-	awxgo "gitlab.com/dhendel/awx-go"
 	"gopkg.in/yaml.v2"
 )
 
@@ -27,17 +30,12 @@ func normalizeJSONOk(s interface{}) (string, bool) {
 	var j interface{}
 	err := json.Unmarshal([]byte(s.(string)), &j)
 	if err != nil {
-		return fmt.Sprintf("Error parsing JSON: %s", err), false
+		return "", false
 	}
 	b, _ := json.Marshal(j)
 	return string(b[:]), true
 }
 
-func normalizeJSON(s interface{}) string {
-	v, _ := normalizeJSONOk(s)
-	return v
-}
-
 func normalizeYamlOk(s interface{}) (string, bool) {
 	if s == nil || s == "" {
 		return "", true
@@ -45,129 +43,251 @@ func normalizeYamlOk(s interface{}) (string, bool) {
 	var j interface{}
 	err := yaml.Unmarshal([]byte(s.(string)), &j)
 	if err != nil {
-		return fmt.Sprintf("Error parsing YAML: %s", err), false
+		return "", false
 	}
 	b, _ := yaml.Marshal(j)
 	return string(b[:]), true
 }
 
-func normalizeYaml(s interface{}) string {
-	v, _ := normalizeYamlOk(s)
-	return v
-}
-
-func getRoleID(d *schema.ResourceData, m interface{}) (int, error) {
-	awx := m.(*awxgo.AWX)
-	switch d.Get("resource_type").(string) {
-	case "inventory":
-		awxService := awx.InventoriesService
-		obj, _, err := awxService.ListInventories(map[string]string{
-			"name":         d.Get("resource_name").(string),
-			"organization": d.Get("organization_id").(string),
-		})
+// parseVariables decodes AWX variables written as JSON or YAML into a JSON compatible dictionary
+func parseVariables(s string) (map[string]interface{}, error) {
+	if strings.TrimSpace(s) == "" {
+		return map[string]interface{}{}, nil
+	}
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		var y interface{}
+		if err := yaml.Unmarshal([]byte(s), &y); err != nil {
+			return nil, fmt.Errorf("is neither valid JSON nor YAML: %s", err)
+		}
+		// Round trip through JSON so YAML and JSON numbers compare equal
+		b, err := json.Marshal(jsonCompatible(y))
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		if d.Get("role").(string) == "admin" {
-			return obj[0].SummaryFields.ObjectRoles.AdminRole.ID, nil
-		} else if d.Get("role").(string) == "use" {
-			return obj[0].SummaryFields.ObjectRoles.UseRole.ID, nil
-		} else if d.Get("role").(string) == "read" {
-			return obj[0].SummaryFields.ObjectRoles.ReadRole.ID, nil
-		} else if d.Get("role").(string) == "update" {
-			return obj[0].SummaryFields.ObjectRoles.UpdateRole.ID, nil
-		} else {
-			return 0, fmt.Errorf("Role not valid for inventory")
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
 		}
+	}
+	if v == nil {
+		return map[string]interface{}{}, nil
+	}
+	vars, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("must be a dictionary of variables")
+	}
+	return vars, nil
+}
 
-	case "team":
-		awxService := awx.TeamService
-		obj, _, err := awxService.ListTeams(map[string]string{
-			"name":         d.Get("resource_name").(string),
-			"organization": d.Get("organization_id").(string),
-		})
-		if err != nil {
-			return 0, err
+// jsonCompatible converts the map[interface{}]interface{} produced by yaml into map[string]interface{}
+func jsonCompatible(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			m[fmt.Sprintf("%v", k)] = jsonCompatible(val)
 		}
-		if d.Get("role").(string) == "admin" {
-			return obj[0].SummaryFields.ObjectRoles.AdminRole.ID, nil
-		} else if d.Get("role").(string) == "member" {
-			return obj[0].SummaryFields.ObjectRoles.MemberRole.ID, nil
-		} else if d.Get("role").(string) == "read" {
-			return obj[0].SummaryFields.ObjectRoles.ReadRole.ID, nil
-		} else {
-			return 0, fmt.Errorf("Role not valid for team object")
-		}
-	case "organization":
-		awxService := awx.OrganizationService
-		obj, _, err := awxService.ListOrganizations(map[string]string{
-			"name": d.Get("resource_name").(string),
-		})
-		if err != nil {
-			return 0, err
+		return m
+	case []interface{}:
+		for i := range t {
+			t[i] = jsonCompatible(t[i])
 		}
-		if d.Get("role").(string) == "admin" {
-			return obj[0].SummaryFields.ObjectRoles.AdminRole.ID, nil
-		} else if d.Get("role").(string) == "member" {
-			return obj[0].SummaryFields.ObjectRoles.MemberRole.ID, nil
-		} else if d.Get("role").(string) == "read" {
-			return obj[0].SummaryFields.ObjectRoles.ReadRole.ID, nil
-		} else if d.Get("role").(string) == "member" {
-			return obj[0].SummaryFields.ObjectRoles.MemberRole.ID, nil
-		} else if d.Get("role").(string) == "workflow admin" {
-			return obj[0].SummaryFields.ObjectRoles.WorkflowAdminRole.ID, nil
-		} else if d.Get("role").(string) == "credential admin" {
-			return obj[0].SummaryFields.ObjectRoles.CredentialAdminRole.ID, nil
-		} else if d.Get("role").(string) == "job template admin" {
-			return obj[0].SummaryFields.ObjectRoles.JobTemplateAdminRole.ID, nil
-		} else if d.Get("role").(string) == "project admin" {
-			return obj[0].SummaryFields.ObjectRoles.ProjectAdminRole.ID, nil
-		} else if d.Get("role").(string) == "auditor" {
-			return obj[0].SummaryFields.ObjectRoles.AuditorRole.ID, nil
-		} else if d.Get("role").(string) == "inventory admin" {
-			return obj[0].SummaryFields.ObjectRoles.InventoryAdminRole.ID, nil
-		} else {
-			return 0, fmt.Errorf("Role not valid for organization object")
+		return t
+	}
+	return v
+}
+
+func validateVariables(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := parseVariables(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q %s", k, err))
+	}
+	return
+}
+
+// suppressEquivalentVariables ignores key order and JSON versus YAML formatting
+func suppressEquivalentVariables(k, old, new string, d *schema.ResourceData) bool {
+	o, err := parseVariables(old)
+	if err != nil {
+		return false
+	}
+	n, err := parseVariables(new)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(o, n)
+}
+
+// getVariables returns the variables payload, variables_map takes precedence over the raw string
+func getVariables(d *schema.ResourceData) (string, error) {
+	if vars, ok := d.GetOk("variables_map"); ok {
+		return expandVariablesMap(vars.(map[string]interface{}))
+	}
+	return d.Get("variables").(string), nil
+}
+
+// setVariables stores AWX variables in the attribute used by the configuration
+func setVariables(d *schema.ResourceData, raw string) {
+	if old, ok := d.GetOk("variables_map"); ok {
+		if vars, err := parseVariables(raw); err == nil {
+			d.Set("variables_map", flattenVariablesMap(vars, old.(map[string]interface{})))
+			d.Set("variables", "")
+			return
 		}
-	case "job_template":
-		awxService := awx.JobTemplateService
-		obj, _, err := awxService.ListJobTemplates(map[string]string{
-			"name": d.Get("resource_name").(string),
-		})
-		if err != nil {
-			return 0, err
+	}
+	d.Set("variables", normalizeJSONYaml(raw))
+}
+
+// expandVariablesMap decodes the JSON encoded values of variables_map into a
+// JSON dictionary, so booleans, numbers and lists keep their type
+func expandVariablesMap(m map[string]interface{}) (string, error) {
+	vars := make(map[string]interface{}, len(m))
+	for k, raw := range m {
+		var v interface{}
+		if err := json.Unmarshal([]byte(raw.(string)), &v); err != nil {
+			return "", fmt.Errorf("variables_map.%s must be JSON encoded, use jsonencode(): %s", k, err)
 		}
-		if d.Get("role").(string) == "admin" {
-			return obj[0].SummaryFields.ObjectRoles.AdminRole.ID, nil
-		} else if d.Get("role").(string) == "execute" {
-			return obj[0].SummaryFields.ObjectRoles.ExecuteRole.ID, nil
-		} else if d.Get("role").(string) == "read" {
-			return obj[0].SummaryFields.ObjectRoles.ReadRole.ID, nil
-		} else {
-			return 0, fmt.Errorf("Role not valid for Job Template")
+		vars[k] = v
+	}
+	b, err := json.Marshal(vars)
+	return string(b), err
+}
+
+// flattenVariablesMap JSON encodes every variable, keeping the configured
+// spelling of values which are equivalent
+func flattenVariablesMap(vars map[string]interface{}, old map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(vars))
+	for k, v := range vars {
+		if o, ok := old[k].(string); ok && jsonValueEqual(o, v) {
+			result[k] = o
+			continue
 		}
-	case "credential":
-		return 0, fmt.Errorf("Credential endpoint not implemeneted")
-	case "project":
-		awxService := awx.ProjectService
-		obj, _, err := awxService.ListProjects(map[string]string{
-			"name":         d.Get("resource_name").(string),
-			"organization": d.Get("organization_id").(string),
-		})
-		if err != nil {
-			return 0, err
+		b, _ := json.Marshal(v)
+		result[k] = string(b)
+	}
+	return result
+}
+
+// jsonValueEqual tells whether the JSON document s decodes to v
+func jsonValueEqual(s string, v interface{}) bool {
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(s), &parsed); err != nil {
+		return false
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return false
+	}
+	var normalized interface{}
+	json.Unmarshal(b, &normalized)
+	return reflect.DeepEqual(parsed, normalized)
+}
+
+func validateVariablesMap(v interface{}, k string) (ws []string, errors []error) {
+	for key, value := range v.(map[string]interface{}) {
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(value.(string)), &parsed); err != nil {
+			errors = append(errors, fmt.Errorf("%s.%s must be JSON encoded, use jsonencode(): %s", k, key, err))
 		}
-		if d.Get("role").(string) == "admin" {
-			return obj[0].SummaryFields.ObjectRoles.AdminRole.ID, nil
-		} else if d.Get("role").(string) == "update" {
-			return obj[0].SummaryFields.ObjectRoles.UpdateRole.ID, nil
-		} else if d.Get("role").(string) == "read" {
-			return obj[0].SummaryFields.ObjectRoles.ReadRole.ID, nil
-		} else if d.Get("role").(string) == "use" {
-			return obj[0].SummaryFields.ObjectRoles.UseRole.ID, nil
-		} else {
-			return 0, fmt.Errorf("Role not valid for Project")
+	}
+	return
+}
+
+// suppressEquivalentVariablesMapValue compares single variables_map values as JSON
+func suppressEquivalentVariablesMapValue(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" || new == "" || strings.HasSuffix(k, ".%") {
+		return false
+	}
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(new), &parsed); err != nil {
+		return false
+	}
+	return jsonValueEqual(old, parsed)
+}
+
+// compositeID joins two numeric IDs into a "first:second" resource ID
+func compositeID(first, second int) string {
+	return fmt.Sprintf("%d:%d", first, second)
+}
+
+// parseCompositeID splits a "first:second" resource ID into its numeric parts
+func parseCompositeID(id string) (int, int, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("Invalid ID %q, expected <id>:<id>", id)
+	}
+	first, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("Invalid ID %q, %q is not numeric", id, parts[0])
+	}
+	second, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("Invalid ID %q, %q is not numeric", id, parts[1])
+	}
+	return first, second, nil
+}
+
+// dataSourceLookupParams builds the list filters of a data source. An id looks
+// up that exact object, otherwise the name is scoped by the attributes of
+// scopes, which map attribute names to query parameters.
+func dataSourceLookupParams(d *schema.ResourceData, scopes map[string]string) (map[string]string, error) {
+	if id, ok := d.GetOk("id"); ok {
+		return map[string]string{"id": strconv.Itoa(id.(int))}, nil
+	}
+	name, ok := d.GetOk("name")
+	if !ok {
+		return nil, fmt.Errorf("One of id or name must be set")
+	}
+	params := map[string]string{"name": name.(string)}
+	for attr, param := range scopes {
+		if v, ok := d.GetOk(attr); ok {
+			params[param] = fmt.Sprintf("%v", v)
 		}
 	}
-	return 0, fmt.Errorf("Not implemented API endpoint")
+	return params, nil
+}
+
+// checkLookupMatches collects the IDs of the count objects a lookup returned,
+// id returns the ID of the i-th one, and checks them with checkLookupResult
+func checkLookupMatches(kind string, filters map[string]string, count int, id func(i int) int) error {
+	ids := make([]int, count)
+	for i := range ids {
+		ids[i] = id(i)
+	}
+	return checkLookupResult(kind, filters, ids)
+}
+
+// checkLookupResult errors unless a data source lookup matched exactly one object
+func checkLookupResult(kind string, filters map[string]string, ids []int) error {
+	var criteria []string
+	for k, v := range filters {
+		criteria = append(criteria, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(criteria)
+	switch len(ids) {
+	case 0:
+		return fmt.Errorf("No %s found matching %s", kind, strings.Join(criteria, ", "))
+	case 1:
+		return nil
+	}
+	candidates := make([]string, len(ids))
+	for i, id := range ids {
+		candidates[i] = strconv.Itoa(id)
+	}
+	return fmt.Errorf("%d %ss match %s, candidate IDs: %s. Narrow the lookup or pass id",
+		len(ids), kind, strings.Join(criteria, ", "), strings.Join(candidates, ", "))
+}
+
+// optionalID returns the id of attr, or nil to clear the relation when it is unset
+func optionalID(d *schema.ResourceData, attr string) interface{} {
+	if id, ok := d.GetOk(attr); ok {
+		return id.(int)
+	}
+	return nil
+}
+
+func intOrZero(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
 }
@@ -0,0 +1,138 @@
+package awx
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestSuppressEquivalentVariables(t *testing.T) {
+	cases := []struct {
+		old, new string
+		equal    bool
+	}{
+		{`{"a": 1, "b": "x"}`, "---\nb: x\na: 1\n", true},
+		{`{"a": 1}`, `{"a": 2}`, false},
+		{"", "---", true},
+		{"", "{}", true},
+		{"a: [1, 2]", `{"a": [1, 2]}`, true},
+		{"a: [1, 2]", `{"a": [2, 1]}`, false},
+	}
+	for _, c := range cases {
+		if got := suppressEquivalentVariables("variables", c.old, c.new, nil); got != c.equal {
+			t.Errorf("suppressEquivalentVariables(%q, %q) = %t, want %t", c.old, c.new, got, c.equal)
+		}
+	}
+}
+
+func TestVariablesMap(t *testing.T) {
+	config := map[string]interface{}{
+		"enabled": "false",
+		"port":    "8080",
+		"zones":   `["a", "b"]`,
+		"role":    `"web"`,
+	}
+	raw, err := expandVariablesMap(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if raw != `{"enabled":false,"port":8080,"role":"web","zones":["a","b"]}` {
+		t.Errorf("expandVariablesMap() = %s", raw)
+	}
+	if _, err := expandVariablesMap(map[string]interface{}{"role": "web"}); err == nil {
+		t.Errorf("expected an error for a value which isn't JSON encoded")
+	}
+	vars, _ := parseVariables("enabled: false\nport: 8080\nzones: [a, b]\nrole: db\n")
+	want := map[string]interface{}{
+		"enabled": "false",
+		"port":    "8080",
+		"zones":   `["a", "b"]`,
+		"role":    `"db"`,
+	}
+	if got := flattenVariablesMap(vars, config); !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenVariablesMap() = %v, want %v", got, want)
+	}
+	if !suppressEquivalentVariablesMapValue("variables_map.zones", `["a","b"]`, `[ "a", "b" ]`, nil) {
+		t.Errorf("equivalent JSON values should not produce a diff")
+	}
+}
+
+func TestValidateVariables(t *testing.T) {
+	if _, errs := validateVariables("a: b\nc: [1, 2]", "variables"); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if _, errs := validateVariables("a: [1, 2", "variables"); len(errs) != 1 {
+		t.Errorf("expected a parse error, got %v", errs)
+	}
+	if _, errs := validateVariables("- a\n- b", "variables"); len(errs) != 1 {
+		t.Errorf("expected a dictionary error, got %v", errs)
+	}
+}
+
+func TestSuppressEquivalentInventoryHost(t *testing.T) {
+	cases := []struct {
+		old, new string
+		equal    bool
+	}{
+		{`{"enabled":true,"groups":[2,1],"variables":{}}`, `{"groups":[1,2]}`, true},
+		{`{"enabled":true,"groups":[],"variables":{"a":1}}`, `{"variables":"a: 1"}`, true},
+		{`{"enabled":true,"groups":[],"variables":{}}`, `{"enabled":false}`, false},
+		{"", `{}`, false},
+		{`{}`, "", false},
+	}
+	for _, c := range cases {
+		if got := suppressEquivalentInventoryHost("hosts.web", c.old, c.new, nil); got != c.equal {
+			t.Errorf("suppressEquivalentInventoryHost(%q, %q) = %t, want %t", c.old, c.new, got, c.equal)
+		}
+	}
+}
+
+func TestCheckLookupResult(t *testing.T) {
+	filters := map[string]string{"name": "web", "inventory": "3"}
+	if err := checkLookupResult("host", filters, []int{7}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	err := checkLookupResult("host", filters, nil)
+	if err == nil || err.Error() != "No host found matching inventory=3, name=web" {
+		t.Errorf("unexpected error for no match: %v", err)
+	}
+	err = checkLookupResult("host", filters, []int{7, 9})
+	if err == nil || err.Error() != "2 hosts match inventory=3, name=web, candidate IDs: 7, 9. Narrow the lookup or pass id" {
+		t.Errorf("unexpected error for multiple matches: %v", err)
+	}
+}
+
+func TestDataSourceLookupParams(t *testing.T) {
+	scopes := map[string]string{"inventory_id": "inventory"}
+	d := schema.TestResourceDataRaw(t, dataSourceHost().Schema, map[string]interface{}{
+		"name":         "web",
+		"inventory_id": 3,
+	})
+	params, err := dataSourceLookupParams(d, scopes)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(params, map[string]string{"name": "web", "inventory": "3"}) {
+		t.Errorf("unexpected params for a name lookup: %v", params)
+	}
+
+	d = schema.TestResourceDataRaw(t, dataSourceHost().Schema, map[string]interface{}{
+		"id":   7,
+		"name": "web",
+	})
+	params, err = dataSourceLookupParams(d, scopes)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(params, map[string]string{"id": "7"}) {
+		t.Errorf("unexpected params for an id lookup: %v", params)
+	}
+
+	d = schema.TestResourceDataRaw(t, dataSourceHost().Schema, map[string]interface{}{
+		"inventory_id": 3,
+	})
+	if _, err := dataSourceLookupParams(d, scopes); err == nil {
+		t.Errorf("expected an error without id or name")
+	}
+}
@@ -0,0 +1,149 @@
+package awx
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Smart inventory host filters are terms like key=value or key__lookup="quoted
+// value" combined with and, or, not and parentheses. The parser below only
+// checks the syntax, AWX still validates the field names on apply.
+
+type hostFilterToken struct {
+	kind  string
+	value string
+	pos   int
+}
+
+func tokenizeHostFilter(s string) ([]hostFilterToken, error) {
+	var tokens []hostFilterToken
+	r := []rune(s)
+	for i := 0; i < len(r); {
+		switch {
+		case unicode.IsSpace(r[i]):
+			i++
+		case r[i] == '(' || r[i] == ')':
+			tokens = append(tokens, hostFilterToken{kind: string(r[i]), pos: i})
+			i++
+		default:
+			start := i
+			for i < len(r) && !unicode.IsSpace(r[i]) && r[i] != '(' && r[i] != ')' && r[i] != '=' && r[i] != '"' && r[i] != '\'' {
+				i++
+			}
+			word := string(r[start:i])
+			if i >= len(r) || r[i] != '=' {
+				switch strings.ToLower(word) {
+				case "and", "or", "not":
+					tokens = append(tokens, hostFilterToken{kind: strings.ToLower(word), pos: start})
+					continue
+				}
+				if word == "" {
+					return nil, fmt.Errorf("unexpected %q at position %d", r[i], i)
+				}
+				return nil, fmt.Errorf("expected key=value at position %d, got %q", start, word)
+			}
+			if word == "" {
+				return nil, fmt.Errorf("missing key before '=' at position %d", i)
+			}
+			i++
+			var value string
+			if i < len(r) && (r[i] == '"' || r[i] == '\'') {
+				quote := r[i]
+				end := i + 1
+				for end < len(r) && r[end] != quote {
+					if r[end] == '\\' {
+						end++
+					}
+					end++
+				}
+				if end >= len(r) {
+					return nil, fmt.Errorf("unterminated quoted value at position %d", i)
+				}
+				value = string(r[i+1 : end])
+				i = end + 1
+			} else {
+				vstart := i
+				for i < len(r) && !unicode.IsSpace(r[i]) && r[i] != '(' && r[i] != ')' {
+					i++
+				}
+				value = string(r[vstart:i])
+				if value == "" {
+					return nil, fmt.Errorf("missing value for %s at position %d", word, vstart)
+				}
+			}
+			tokens = append(tokens, hostFilterToken{kind: "term", value: word + "=" + value, pos: start})
+		}
+	}
+	return tokens, nil
+}
+
+// parseHostFilter checks the syntax of a smart inventory host filter
+func parseHostFilter(s string) error {
+	tokens, err := tokenizeHostFilter(s)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		return nil
+	}
+	p := &hostFilterParser{tokens: tokens}
+	if err := p.expr(); err != nil {
+		return err
+	}
+	if p.i < len(p.tokens) {
+		return fmt.Errorf("unexpected %q at position %d", p.tokens[p.i].kind, p.tokens[p.i].pos)
+	}
+	return nil
+}
+
+type hostFilterParser struct {
+	tokens []hostFilterToken
+	i      int
+}
+
+func (p *hostFilterParser) expr() error {
+	if err := p.term(); err != nil {
+		return err
+	}
+	for p.i < len(p.tokens) && (p.tokens[p.i].kind == "and" || p.tokens[p.i].kind == "or") {
+		p.i++
+		if err := p.term(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *hostFilterParser) term() error {
+	if p.i >= len(p.tokens) {
+		return fmt.Errorf("unexpected end of filter")
+	}
+	t := p.tokens[p.i]
+	switch t.kind {
+	case "not":
+		p.i++
+		return p.term()
+	case "(":
+		p.i++
+		if err := p.expr(); err != nil {
+			return err
+		}
+		if p.i >= len(p.tokens) || p.tokens[p.i].kind != ")" {
+			return fmt.Errorf("missing ')' for '(' at position %d", t.pos)
+		}
+		p.i++
+		return nil
+	case "term":
+		p.i++
+		return nil
+	}
+	return fmt.Errorf("unexpected %q at position %d", t.kind, t.pos)
+}
+
+func validateHostFilter(v interface{}, k string) (ws []string, errors []error) {
+	if err := parseHostFilter(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q is not a valid host filter: %s", k, err))
+	}
+	return
+}
@@ -0,0 +1,37 @@
+package awx
+
+import (
+	"testing"
+)
+
+func TestParseHostFilter(t *testing.T) {
+	valid := []string{
+		"",
+		"name__icontains=web",
+		`name__startswith="web 01" and enabled=true`,
+		"not (groups__name=db or groups__name=cache) and ansible_facts__ansible_distribution=Ubuntu",
+		`name="it's"`,
+	}
+	for _, f := range valid {
+		if err := parseHostFilter(f); err != nil {
+			t.Errorf("parseHostFilter(%q) unexpected error: %s", f, err)
+		}
+	}
+
+	invalid := []string{
+		"web",
+		"name=",
+		"=web",
+		`name="web`,
+		"name=web and",
+		"(name=web",
+		"name=web)",
+		"name=web name=db",
+		"and name=web",
+	}
+	for _, f := range invalid {
+		if err := parseHostFilter(f); err == nil {
+			t.Errorf("parseHostFilter(%q) expected an error", f)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package awx
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// roleObjectTypes maps every role-bearing resource type to its api collection
+// and whether names are only unique per organization. The roles valid for an
+// object are not hardcoded, they are discovered from its summary_fields.object_roles.
+var roleObjectTypes = map[string]struct {
+	collection string
+	scoped     bool
+}{
+	"organization":          {"organizations", false},
+	"team":                  {"teams", true},
+	"inventory":             {"inventories", true},
+	"project":               {"projects", true},
+	"job_template":          {"job_templates", true},
+	"workflow_job_template": {"workflow_job_templates", true},
+	"credential":            {"credentials", true},
+	"instance_group":        {"instance_groups", false},
+	"notification_template": {"notification_templates", true},
+}
+
+// roleResourceTypes lists the keys of roleObjectTypes, sorted
+func roleResourceTypes() []string {
+	var types []string
+	for t := range roleObjectTypes {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// objectRoles are the roles of one AWX object, keyed by normalized role name
+type objectRoles struct {
+	resourceType string
+	resourceID   int
+	resourceName string
+	roles        map[string]int
+}
+
+// normalizeRoleName accepts the object_roles keys ("job_template_admin_role")
+// as well as their short form ("job template admin") and returns the short form
+func normalizeRoleName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.Replace(name, "_", " ", -1)
+	return strings.TrimSuffix(name, " role")
+}
+
+// names returns the sorted role names of the object
+func (o *objectRoles) names() []string {
+	var names []string
+	for n := range o.roles {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// roleID returns the ID of the named role, the error lists the valid roles
+func (o *objectRoles) roleID(name string) (int, error) {
+	if id, ok := o.roles[normalizeRoleName(name)]; ok {
+		return id, nil
+	}
+	return 0, fmt.Errorf("Role %q is not valid for %s %d, valid roles are: %s",
+		name, o.resourceType, o.resourceID, strings.Join(o.names(), ", "))
+}
+
+// roleName returns the name of the role with the given ID
+func (o *objectRoles) roleName(id int) (string, bool) {
+	for n, i := range o.roles {
+		if i == id {
+			return n, true
+		}
+	}
+	return "", false
+}
+
+// roleObject is the part of any role-bearing api object needed to find its roles
+type roleObject struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	SummaryFields struct {
+		ObjectRoles map[string]struct {
+			ID int `json:"id"`
+		} `json:"object_roles"`
+	} `json:"summary_fields"`
+}
+
+// getObjectRoles looks up exactly one object of resourceType matching params and returns its roles
+func getObjectRoles(awx *AWXClient, resourceType string, params map[string]string) (*objectRoles, error) {
+	t, ok := roleObjectTypes[resourceType]
+	if !ok {
+		return nil, fmt.Errorf("Resource type %q has no roles, valid types are: %s",
+			resourceType, strings.Join(roleResourceTypes(), ", "))
+	}
+	var objects []*roleObject
+	err := awx.apiList(fmt.Sprintf("/api/v2/%s/", t.collection), params, func(raw json.RawMessage) error {
+		obj := new(roleObject)
+		if err := json.Unmarshal(raw, obj); err != nil {
+			return err
+		}
+		objects = append(objects, obj)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	kind := strings.Replace(resourceType, "_", " ", -1)
+	if err := checkLookupMatches(kind, params, len(objects), func(i int) int { return objects[i].ID }); err != nil {
+		return nil, err
+	}
+	return newObjectRoles(resourceType, objects[0]), nil
+}
+
+// getObjectRolesByID returns the roles of the object resourceType/id, an
+// object which doesn't exist is reported with an apiNotFoundError
+func getObjectRolesByID(awx *AWXClient, resourceType string, id int) (*objectRoles, error) {
+	t, ok := roleObjectTypes[resourceType]
+	if !ok {
+		return nil, fmt.Errorf("Resource type %q has no roles, valid types are: %s",
+			resourceType, strings.Join(roleResourceTypes(), ", "))
+	}
+	obj := new(roleObject)
+	if err := awx.apiGet(fmt.Sprintf("/api/v2/%s/%d/", t.collection, id), obj, nil); err != nil {
+		return nil, err
+	}
+	return newObjectRoles(resourceType, obj), nil
+}
+
+func newObjectRoles(resourceType string, obj *roleObject) *objectRoles {
+	result := &objectRoles{
+		resourceType: resourceType,
+		resourceID:   obj.ID,
+		resourceName: obj.Name,
+		roles:        make(map[string]int),
+	}
+	for key, r := range obj.SummaryFields.ObjectRoles {
+		result.roles[normalizeRoleName(key)] = r.ID
+	}
+	return result
+}
+
+// roleResourceParams builds the lookup of the object a role belongs to, by
+// resource_id or by resource_name scoped to the organization when relevant
+func roleResourceParams(d *schema.ResourceData) map[string]string {
+	if id, ok := d.GetOk("resource_id"); ok {
+		return map[string]string{"id": strconv.Itoa(id.(int))}
+	}
+	params := map[string]string{"name": d.Get("resource_name").(string)}
+	if org, ok := d.GetOk("organization_id"); ok && roleObjectTypes[d.Get("resource_type").(string)].scoped {
+		params["organization"] = fmt.Sprintf("%v", org)
+	}
+	return params
+}
+
+// getRoleID resolves the role of the resource_type, resource_id or
+// resource_name and role attributes
+func getRoleID(d *schema.ResourceData, m interface{}) (int, error) {
+	awx := m.(*AWXClient)
+	roles, err := getObjectRoles(awx, d.Get("resource_type").(string), roleResourceParams(d))
+	if err != nil {
+		return 0, err
+	}
+	return roles.roleID(d.Get("role").(string))
+}
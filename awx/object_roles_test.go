@@ -0,0 +1,38 @@
+package awx
+
+import (
+	"testing"
+)
+
+func TestNormalizeRoleName(t *testing.T) {
+	cases := map[string]string{
+		"job_template_admin_role": "job template admin",
+		"job template admin":      "job template admin",
+		"Admin":                   "admin",
+		"adhoc_role":              "adhoc",
+		"execute":                 "execute",
+	}
+	for in, want := range cases {
+		if got := normalizeRoleName(in); got != want {
+			t.Errorf("normalizeRoleName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestObjectRolesRoleID(t *testing.T) {
+	roles := &objectRoles{
+		resourceType: "inventory",
+		resourceID:   3,
+		roles:        map[string]int{"admin": 10, "adhoc": 11, "use": 12},
+	}
+	if id, err := roles.roleID("adhoc_role"); err != nil || id != 11 {
+		t.Errorf("roleID(adhoc_role) = %d, %v", id, err)
+	}
+	_, err := roles.roleID("execute")
+	if err == nil || err.Error() != `Role "execute" is not valid for inventory 3, valid roles are: adhoc, admin, use` {
+		t.Errorf("unexpected error for an invalid role: %v", err)
+	}
+	if name, ok := roles.roleName(12); !ok || name != "use" {
+		t.Errorf("roleName(12) = %q, %t", name, ok)
+	}
+}
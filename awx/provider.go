@@ -48,22 +48,51 @@ func Provider() terraform.ResourceProvider {
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"awx_inventory":         resourceInventoryObject(),
-			"awx_inventory_group":   resourceInventoryGroupObject(),
-			"awx_host":              resourceHostObject(),
-			"awx_group_association": resourceGroupAssociationObject(),
-			"awx_project":           resourceProjectObject(),
-			"awx_job_template":      resourceJobTemplateObject(),
-			"awx_user":              resourceUserObject(),
-			"awx_team":              resourceTeamObject(),
-			"awx_user_role":         resourceUserRoleObject(),
-			"awx_team_role":         resourceTeamRoleObject(),
-			"awx_organization":      resourceOrganizationObject(),
+			"awx_inventory":               resourceInventoryObject(),
+			"awx_constructed_inventory":   resourceConstructedInventoryObject(),
+			"awx_inventory_group":         resourceInventoryGroupObject(),
+			"awx_host":                    resourceHostObject(),
+			"awx_inventory_hosts":         resourceInventoryHostsObject(),
+			"awx_group_association":       resourceGroupAssociationObject(),
+			"awx_project":                 resourceProjectObject(),
+			"awx_job_template":            resourceJobTemplateObject(),
+			"awx_execution_environment":   resourceExecutionEnvironmentObject(),
+			"awx_user":                    resourceUserObject(),
+			"awx_team":                    resourceTeamObject(),
+			"awx_team_membership":         resourceTeamMembershipObject(),
+			"awx_team_member":             resourceTeamMemberObject(),
+			"awx_user_role":               resourceUserRoleObject(),
+			"awx_team_role":               resourceTeamRoleObject(),
+			"awx_object_access":           resourceObjectAccessObject(),
+			"awx_organization":            resourceOrganizationObject(),
+			"awx_organization_member":     resourceOrganizationMemberObject(),
+			"awx_organization_admin":      resourceOrganizationAdminObject(),
+			"awx_oauth2_application":      resourceOAuth2ApplicationObject(),
+			"awx_oauth2_token":            resourceOAuth2TokenObject(),
+			"awx_settings":                resourceSettingsObject(),
+			"awx_settings_ldap":           resourceSettingsLDAPObject(),
+			"awx_settings_saml":           resourceSettingsSAMLObject(),
+			"awx_settings_oidc":           resourceSettingsOIDCObject(),
+			"awx_settings_github_org":     resourceSettingsGithubOrgObject(),
+			"awx_settings_github_team":    resourceSettingsGithubTeamObject(),
+			"awx_settings_azuread_oauth2": resourceSettingsAzureADOAuth2Object(),
+			"awx_settings_google_oauth2":  resourceSettingsGoogleOAuth2Object(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"awx_project":      dataSourceProjectObject(),
-			"awx_inventory":    dataSourceInventory(),
-			"awx_job_template": dataSourceJobTemplate(),
+			"awx_project":         dataSourceProjectObject(),
+			"awx_inventory":       dataSourceInventory(),
+			"awx_inventory_group": dataSourceInventoryGroup(),
+			"awx_host":            dataSourceHost(),
+			"awx_job_template":    dataSourceJobTemplate(),
+			"awx_job_templates":   dataSourceJobTemplates(),
+			"awx_projects":        dataSourceProjects(),
+			"awx_inventories":     dataSourceInventories(),
+			"awx_hosts":           dataSourceHosts(),
+			"awx_credentials":     dataSourceCredentials(),
+			"awx_users":           dataSourceUsers(),
+			"awx_teams":           dataSourceTeams(),
+			"awx_role":            dataSourceRole(),
+			"awx_user":            dataSourceUser(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -0,0 +1,261 @@
+package awx
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	awxgo "gitlab.com/dhendel/awx-go"
+)
+
+func resourceConstructedInventoryObject() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceConstructedInventoryCreate,
+		Read:   resourceConstructedInventoryRead,
+		Delete: resourceConstructedInventoryDelete,
+		Update: resourceConstructedInventoryUpdate,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"organization_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"input_inventory_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Required:    true,
+				MinItems:    1,
+				Description: "Ordered list of the inventories combined by the constructed inventory",
+			},
+			"source_vars": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "",
+				StateFunc:        normalizeJSONYaml,
+				ValidateFunc:     validateVariables,
+				DiffSuppressFunc: suppressEquivalentVariables,
+				Description:      "Configuration of the constructed inventory plugin",
+			},
+			"limit": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Host pattern limiting the hosts of the input inventories",
+			},
+			"update_cache_timeout": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+			"variables": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "",
+				StateFunc:        normalizeJSONYaml,
+				ValidateFunc:     validateVariables,
+				DiffSuppressFunc: suppressEquivalentVariables,
+			},
+			"host_count": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of hosts found by the last sync",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+// constructedInventory represents the awx api constructed inventory, awx-go doesn't implement it
+type constructedInventory struct {
+	ID                 int    `json:"id"`
+	Name               string `json:"name"`
+	Description        string `json:"description"`
+	Organization       int    `json:"organization"`
+	Variables          string `json:"variables"`
+	SourceVars         string `json:"source_vars"`
+	Limit              string `json:"limit"`
+	UpdateCacheTimeout int    `json:"update_cache_timeout"`
+	TotalHosts         int    `json:"total_hosts"`
+}
+
+// inventoryUpdateLaunch is one entry of the update_inventory_sources response
+type inventoryUpdateLaunch struct {
+	InventoryUpdate int    `json:"inventory_update"`
+	InventorySource int    `json:"inventory_source"`
+	Status          string `json:"status"`
+}
+
+func resourceConstructedInventoryCreate(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+
+	_, res, err := awx.InventoriesService.ListInventories(map[string]string{
+		"name":         d.Get("name").(string),
+		"organization": strconv.Itoa(d.Get("organization_id").(int)),
+	})
+	if err != nil {
+		return err
+	}
+	if len(res.Results) >= 1 {
+		return fmt.Errorf("Inventory %s with id %d already exists", res.Results[0].Name, res.Results[0].ID)
+	}
+
+	result := new(constructedInventory)
+	err = awx.apiPost("/api/v2/constructed_inventories/", constructedInventoryPayload(d), result)
+	if err != nil {
+		return err
+	}
+	d.SetId(strconv.Itoa(result.ID))
+
+	err = awx.apiReplaceOrderedAssociations(constructedInventoryInputsEndpoint(result.ID), nil, d.Get("input_inventory_ids").([]interface{}))
+	if err != nil {
+		return err
+	}
+	if err := syncConstructedInventory(awx, result.ID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+	return resourceConstructedInventoryRead(d, m)
+}
+
+func resourceConstructedInventoryUpdate(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+
+	err = awx.apiPatch(fmt.Sprintf("/api/v2/constructed_inventories/%d/", id), constructedInventoryPayload(d), nil)
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("input_inventory_ids") {
+		o, n := d.GetChange("input_inventory_ids")
+		if err := awx.apiReplaceOrderedAssociations(constructedInventoryInputsEndpoint(id), o.([]interface{}), n.([]interface{})); err != nil {
+			return err
+		}
+	}
+	if d.HasChange("input_inventory_ids") || d.HasChange("source_vars") || d.HasChange("limit") ||
+		d.HasChange("update_cache_timeout") {
+		if err := syncConstructedInventory(awx, id, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+	return resourceConstructedInventoryRead(d, m)
+}
+
+func resourceConstructedInventoryRead(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Constructed inventory %s not found", d.Id())
+	}
+	r := new(constructedInventory)
+	if err := awx.apiGet(fmt.Sprintf("/api/v2/constructed_inventories/%d/", id), r, nil); err != nil {
+		if isAPINotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	inputs, err := awx.apiListIDs(constructedInventoryInputsEndpoint(id), nil)
+	if err != nil {
+		return err
+	}
+	d = setConstructedInventoryResourceData(d, r)
+	d.Set("input_inventory_ids", inputs)
+	return nil
+}
+
+func resourceConstructedInventoryDelete(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+	if err := awx.apiDelete(fmt.Sprintf("/api/v2/constructed_inventories/%d/", id)); err != nil && !isAPINotFound(err) {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+func constructedInventoryPayload(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                 d.Get("name").(string),
+		"description":          d.Get("description").(string),
+		"organization":         d.Get("organization_id").(int),
+		"source_vars":          d.Get("source_vars").(string),
+		"limit":                d.Get("limit").(string),
+		"update_cache_timeout": d.Get("update_cache_timeout").(int),
+		"variables":            d.Get("variables").(string),
+	}
+}
+
+// constructedInventoryInputsEndpoint lists the input inventories, AWX keeps
+// them in association order
+func constructedInventoryInputsEndpoint(id int) string {
+	return fmt.Sprintf("/api/v2/constructed_inventories/%d/input_inventories/", id)
+}
+
+// syncConstructedInventory launches the constructed inventory source and waits for it
+func syncConstructedInventory(awx *AWXClient, id int, timeout time.Duration) error {
+	var launches []inventoryUpdateLaunch
+	err := awx.apiPost(fmt.Sprintf("/api/v2/inventories/%d/update_inventory_sources/", id), map[string]interface{}{}, &launches)
+	if err != nil {
+		return fmt.Errorf("Failed to launch sync of constructed inventory %d: %s", id, err)
+	}
+	for _, l := range launches {
+		if err := waitForInventoryUpdate(awx, l.InventoryUpdate, timeout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func waitForInventoryUpdate(awx *AWXClient, jobID int, timeout time.Duration) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		job := new(awxgo.Job)
+		if err := awx.apiGet(fmt.Sprintf("/api/v2/inventory_updates/%d/", jobID), job, nil); err != nil {
+			return resource.NonRetryableError(err)
+		}
+		switch job.Status {
+		case "successful":
+			return nil
+		case "failed", "error", "canceled":
+			return resource.NonRetryableError(fmt.Errorf("Inventory update %d finished with status %s: %s",
+				jobID, job.Status, job.JobExplanation))
+		}
+		return resource.RetryableError(fmt.Errorf("Inventory update %d is %s", jobID, job.Status))
+	})
+}
+
+func setConstructedInventoryResourceData(d *schema.ResourceData, r *constructedInventory) *schema.ResourceData {
+	d.Set("name", r.Name)
+	d.Set("description", r.Description)
+	d.Set("organization_id", r.Organization)
+	d.Set("source_vars", normalizeJSONYaml(r.SourceVars))
+	d.Set("limit", r.Limit)
+	d.Set("update_cache_timeout", r.UpdateCacheTimeout)
+	d.Set("variables", normalizeJSONYaml(r.Variables))
+	d.Set("host_count", r.TotalHosts)
+	return d
+}
@@ -0,0 +1,58 @@
+package awx
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+// awx_constructed_inventory test case, the second step reverses the input inventories
+func TestAccAWXConstructedInventory(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { TestAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConstructedInventoryConfig("awx_inventory.a.id, awx_inventory.b.id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_constructed_inventory.testacc", "input_inventory_ids.#", "2"),
+					resource.TestCheckResourceAttrPair("awx_constructed_inventory.testacc", "input_inventory_ids.0", "awx_inventory.a", "id"),
+					resource.TestCheckResourceAttr("awx_constructed_inventory.testacc", "limit", "web"),
+				),
+			},
+			{
+				Config: testAccConstructedInventoryConfig("awx_inventory.b.id, awx_inventory.a.id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("awx_constructed_inventory.testacc", "input_inventory_ids.0", "awx_inventory.b", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccConstructedInventoryConfig(inputs string) string {
+	return `
+resource "awx_inventory" "a" {
+	name = "testacc-constructed-a"
+	organization_id = 1
+}
+
+resource "awx_inventory" "b" {
+	name = "testacc-constructed-b"
+	organization_id = 1
+}
+
+resource "awx_constructed_inventory" "testacc" {
+	name = "testacc-constructed"
+	organization_id = 1
+	input_inventory_ids = [` + inputs + `]
+	limit = "web"
+	source_vars = <<VARS
+plugin: constructed
+strict: true
+groups:
+  web: inventory_hostname.startswith('web')
+VARS
+}
+`
+}
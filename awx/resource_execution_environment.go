@@ -0,0 +1,163 @@
+package awx
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceExecutionEnvironmentObject() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceExecutionEnvironmentCreate,
+		Read:   resourceExecutionEnvironmentRead,
+		Delete: resourceExecutionEnvironmentDelete,
+		Update: resourceExecutionEnvironmentUpdate,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"image": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Full image location, including the registry and tag",
+			},
+			"pull": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validation.StringInSlice([]string{"", "always", "missing", "never"}, false),
+				Description:  "Pull policy, one of always, missing or never. Empty uses the AWX default",
+			},
+			"credential_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Container registry credential used to pull the image",
+			},
+			"organization_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Organization the execution environment belongs to, unset makes it available to all organizations",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(1 * time.Minute),
+		},
+	}
+}
+
+// executionEnvironment represents the awx api execution environment, awx-go doesn't implement it
+type executionEnvironment struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Image        string `json:"image"`
+	Pull         string `json:"pull"`
+	Credential   *int   `json:"credential"`
+	Organization *int   `json:"organization"`
+}
+
+func resourceExecutionEnvironmentCreate(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	result := new(executionEnvironment)
+	if err := awx.apiPost("/api/v2/execution_environments/", executionEnvironmentPayload(d), result); err != nil {
+		return err
+	}
+	d.SetId(strconv.Itoa(result.ID))
+	return resourceExecutionEnvironmentRead(d, m)
+}
+
+func resourceExecutionEnvironmentUpdate(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+	if err := awx.apiPatch(fmt.Sprintf("/api/v2/execution_environments/%d/", id), executionEnvironmentPayload(d), nil); err != nil {
+		return err
+	}
+	return resourceExecutionEnvironmentRead(d, m)
+}
+
+func resourceExecutionEnvironmentRead(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Execution environment %s not found", d.Id())
+	}
+	r := new(executionEnvironment)
+	if err := awx.apiGet(fmt.Sprintf("/api/v2/execution_environments/%d/", id), r, nil); err != nil {
+		if isAPINotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	d = setExecutionEnvironmentResourceData(d, r)
+	return nil
+}
+
+func resourceExecutionEnvironmentDelete(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+	if err := awx.apiDelete(fmt.Sprintf("/api/v2/execution_environments/%d/", id)); err != nil && !isAPINotFound(err) {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+func executionEnvironmentPayload(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"name":         d.Get("name").(string),
+		"description":  d.Get("description").(string),
+		"image":        d.Get("image").(string),
+		"pull":         d.Get("pull").(string),
+		"credential":   optionalID(d, "credential_id"),
+		"organization": optionalID(d, "organization_id"),
+	}
+}
+
+func setExecutionEnvironmentResourceData(d *schema.ResourceData, r *executionEnvironment) *schema.ResourceData {
+	d.Set("name", r.Name)
+	d.Set("description", r.Description)
+	d.Set("image", r.Image)
+	d.Set("pull", r.Pull)
+	d.Set("credential_id", intOrZero(r.Credential))
+	d.Set("organization_id", intOrZero(r.Organization))
+	return d
+}
+
+// executionEnvironmentFields are the execution environment fields of job
+// templates, projects and organizations, which awx-go doesn't expose.
+// Servers without execution environments omit them.
+type executionEnvironmentFields struct {
+	ExecutionEnvironment *int `json:"execution_environment"`
+	DefaultEnvironment   *int `json:"default_environment"`
+}
+
+func getExecutionEnvironmentFields(awx *AWXClient, endpoint string) (*executionEnvironmentFields, error) {
+	r := new(executionEnvironmentFields)
+	if err := awx.apiGet(endpoint, r, nil); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -0,0 +1,48 @@
+package awx
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+// awx_execution_environment test case
+func TestAccAWXExecutionEnvironment(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { TestAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExecutionEnvironmentConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_execution_environment.testacc-ee", "pull", "missing"),
+					resource.TestCheckResourceAttr("awx_execution_environment.testacc-ee", "organization_id", "1"),
+					resource.TestCheckResourceAttrPair("awx_project.testacc-ee", "execution_environment_id",
+						"awx_execution_environment.testacc-ee", "id"),
+				),
+			},
+			{
+				ResourceName:      "awx_execution_environment.testacc-ee",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccExecutionEnvironmentConfig = `
+resource "awx_execution_environment" "testacc-ee" {
+	name = "testacc-ee"
+	image = "quay.io/ansible/awx-ee:latest"
+	pull = "missing"
+	organization_id = 1
+}
+
+resource "awx_project" "testacc-ee" {
+	name = "testacc-ee"
+	organization_id = 1
+	scm_type = "git"
+	scm_url = "https://github.com/ansible/ansible-tower-samples"
+	execution_environment_id = "${awx_execution_environment.testacc-ee.id}"
+}
+`
@@ -3,27 +3,31 @@ package awx
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
-	awxgo "gitlab.com/dhendel/awx-go"
 )
 
+// resourceGroupAssociationObject manages a single host to group membership. It
+// is non-authoritative, so it must not be combined with group_ids on the same awx_host.
 func resourceGroupAssociationObject() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceGroupAssociationCreate,
 		Read:   resourceGroupAssociationRead,
 		Delete: resourceGroupAssociationDelete,
-		Update: resourceGroupAssociationUpdate,
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:       schema.TypeString,
+				Optional:   true,
+				ForceNew:   true,
+				Deprecated: "name is not used, the association is identified by host_id and group_id",
 			},
 			"inventory_id": &schema.Schema{
 				Type:     schema.TypeInt,
 				Required: true,
+				ForceNew: true,
 			},
 			"host_id": &schema.Schema{
 				Type:     schema.TypeInt,
@@ -42,14 +46,13 @@ func resourceGroupAssociationObject() *schema.Resource {
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(1 * time.Minute),
-			Update: schema.DefaultTimeout(1 * time.Minute),
 			Delete: schema.DefaultTimeout(1 * time.Minute),
 		},
 	}
 }
 
 func resourceGroupAssociationCreate(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxServiceHost := awx.HostService
 	awxServiceGroup := awx.GroupService
 	var id, inv int
@@ -71,28 +74,51 @@ func resourceGroupAssociationCreate(d *schema.ResourceData, m interface{}) error
 		return fmt.Errorf("Group %d not found in inventory %d", d.Get("group_id").(int), inv)
 	}
 
-	result, err := awxServiceHost.AssociateGroup(d.Get("host_id").(int), map[string]interface{}{
+	_, err := awxServiceHost.AssociateGroup(d.Get("host_id").(int), map[string]interface{}{
 		"id": d.Get("group_id").(int),
 	}, map[string]string{})
 	if err != nil {
 		return err
 	}
 
-	d.SetId(strconv.Itoa(result.ID))
+	d.SetId(compositeID(d.Get("host_id").(int), d.Get("group_id").(int)))
 	return resourceGroupAssociationRead(d, m)
 }
 
-func resourceGroupAssociationUpdate(d *schema.ResourceData, m interface{}) error {
-	d = setGroupAssociationResourceData(d)
-	return nil
-}
-
 func resourceGroupAssociationRead(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	// IDs used to be the bare host ID, upgrade them to host_id:group_id
+	if !strings.Contains(d.Id(), ":") {
+		d.SetId(compositeID(d.Get("host_id").(int), d.Get("group_id").(int)))
+	}
+	hostID, groupID, err := parseCompositeID(d.Id())
+	if err != nil {
+		return err
+	}
+	_, res, err := awx.HostService.ListHosts(map[string]string{"id": strconv.Itoa(hostID)})
+	if err != nil {
+		return err
+	}
+	if len(res.Results) == 0 {
+		d.SetId("")
+		return nil
+	}
+	groups, err := getHostGroupIDs(awx, hostID)
+	if err != nil {
+		return err
+	}
+	for _, g := range groups {
+		if g == groupID {
+			d = setGroupAssociationResourceData(d, res.Results[0].Inventory, hostID, groupID)
+			return nil
+		}
+	}
+	d.SetId("")
 	return nil
 }
 
 func resourceGroupAssociationDelete(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxServiceHost := awx.HostService
 	awxServiceGroup := awx.GroupService
 	var id, inv int
@@ -103,7 +129,8 @@ func resourceGroupAssociationDelete(d *schema.ResourceData, m interface{}) error
 		"inventory": strconv.Itoa(inv)},
 	)
 	if len(resHost.Results) == 0 {
-		return fmt.Errorf("Host %d not found in inventory %d", d.Get("host_id").(int), inv)
+		d.SetId("")
+		return nil
 	}
 	id = d.Get("group_id").(int)
 	_, resGroup, _ := awxServiceGroup.ListGroups(map[string]string{
@@ -111,7 +138,8 @@ func resourceGroupAssociationDelete(d *schema.ResourceData, m interface{}) error
 		"inventory": strconv.Itoa(inv)},
 	)
 	if len(resGroup.Results) == 0 {
-		return fmt.Errorf("Group %d not found in inventory %d", d.Get("group_id").(int), inv)
+		d.SetId("")
+		return nil
 	}
 
 	_, err := awxServiceHost.DisAssociateGroup(d.Get("host_id").(int), map[string]interface{}{
@@ -122,14 +150,13 @@ func resourceGroupAssociationDelete(d *schema.ResourceData, m interface{}) error
 	}
 
 	d.SetId("")
-	return resourceGroupAssociationRead(d, m)
+	return nil
 }
 
-func setGroupAssociationResourceData(d *schema.ResourceData) *schema.ResourceData {
-	d.Set("name", d.Get("name").(string))
-	d.Set("inventory_id", d.Get("inventory_id").(int))
-	d.Set("host_id", d.Get("host_id").(int))
-	d.Set("group_id", d.Get("group_id").(int))
+func setGroupAssociationResourceData(d *schema.ResourceData, inventoryID, hostID, groupID int) *schema.ResourceData {
+	d.Set("inventory_id", inventoryID)
+	d.Set("host_id", hostID)
+	d.Set("group_id", groupID)
 	return d
 
 }
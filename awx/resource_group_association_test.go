@@ -21,6 +21,12 @@ func TestAccAWXGroupAssociation(t *testing.T) {
 					testAccCheckStateGroupAssociation("inventory_id", "1"),
 				),
 			},
+			{
+				ResourceName:            "awx_group_association.k8s-node-1_k8s-nodes",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"name"},
+			},
 		},
 	})
 }
@@ -50,6 +56,7 @@ resource "awx_host" "testacc-host_1" {
 	name         = "testacc-host_1"
 	description  = "AWX Acc test host"
 	inventory_id = "1"
+	manage_groups = false
 	variables = <<VARIABLES
 ---
 api_server_enabled: false
@@ -30,9 +30,16 @@ func resourceHostObject() *schema.Resource {
 				Required: true,
 			},
 			"group_ids": &schema.Schema{
-				Type:     schema.TypeList,
-				Elem:     &schema.Schema{Type: schema.TypeInt},
-				Optional: true,
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Optional:    true,
+				Description: "Authoritative set of group IDs the host belongs to, the host leaves every group missing from it",
+			},
+			"manage_groups": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Manage the group memberships with group_ids, set to false when they are managed with awx_group_association",
 			},
 			"enabled": &schema.Schema{
 				Type:     schema.TypeBool,
@@ -45,21 +52,42 @@ func resourceHostObject() *schema.Resource {
 				Default:  "",
 			},
 			"variables": &schema.Schema{
-				Type:      schema.TypeString,
-				Optional:  true,
-				Default:   "",
-				StateFunc: normalizeJSONYaml,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "",
+				StateFunc:        normalizeJSONYaml,
+				ValidateFunc:     validateVariables,
+				DiffSuppressFunc: suppressEquivalentVariables,
+				ConflictsWith:    []string{"variables_map"},
+			},
+			"variables_map": &schema.Schema{
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				ValidateFunc:     validateVariablesMap,
+				DiffSuppressFunc: suppressEquivalentVariablesMapValue,
+				Description:      "Map of JSON encoded variables, e.g. enabled = jsonencode(false), so values keep their type",
+				ConflictsWith:    []string{"variables"},
 			},
 		},
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+
+		CustomizeDiff: resourceHostCustomizeDiff,
 	}
 }
 
+func resourceHostCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if !d.Get("manage_groups").(bool) && d.Get("group_ids").(*schema.Set).Len() > 0 {
+		return fmt.Errorf("group_ids can't be set when manage_groups is false")
+	}
+	return nil
+}
+
 func resourceHostCreate(d *schema.ResourceData, m interface{}) error {
 
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.HostService
 
 	inv := d.Get("inventory_id").(int)
@@ -71,43 +99,44 @@ func resourceHostCreate(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("Host %s with id %d already exists", res.Results[0].Name, res.Results[0].ID)
 	}
 
+	variables, err := getVariables(d)
+	if err != nil {
+		return err
+	}
+
 	result, err := awxService.CreateHost(map[string]interface{}{
 		"name":        d.Get("name").(string),
 		"description": d.Get("description").(string),
 		"inventory":   d.Get("inventory_id").(int),
 		"enabled":     d.Get("enabled").(bool),
 		"instance_id": d.Get("instance_id").(string),
-		"variables":   d.Get("variables").(string),
+		"variables":   variables,
 	}, map[string]string{})
 	if err != nil {
 		return err
 	}
 
-	hostID := result.ID
-	if d.IsNewResource() {
-		rawGroups := d.Get("group_ids").([]interface{})
-		for _, v := range rawGroups {
-
-			_, err := awxService.AssociateGroup(hostID, map[string]interface{}{
-				"id": v.(int),
-			}, map[string]string{})
-			if err != nil {
-				return err
-			}
+	d.SetId(strconv.Itoa(result.ID))
+	if d.Get("manage_groups").(bool) {
+		if err := setHostGroups(awx, result.ID, d.Get("group_ids").(*schema.Set)); err != nil {
+			return err
 		}
 	}
-	d.SetId(strconv.Itoa(result.ID))
 	return resourceHostRead(d, m)
 }
 
 func resourceHostUpdate(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.HostService
 	_, res, _ := awxService.ListHosts(map[string]string{"id": d.Id()})
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
 		return err
 	}
+	variables, err := getVariables(d)
+	if err != nil {
+		return err
+	}
 	if len(res.Results) >= 1 {
 
 		_, err = awxService.UpdateHost(id, map[string]interface{}{
@@ -116,22 +145,16 @@ func resourceHostUpdate(d *schema.ResourceData, m interface{}) error {
 			"inventory":   d.Get("inventory_id").(int),
 			"enabled":     d.Get("enabled").(bool),
 			"instance_id": d.Get("instance_id").(string),
-			"variables":   d.Get("variables").(string),
+			"variables":   variables,
 		}, nil)
 		if err != nil {
 			return err
 		}
 
-		if d.HasChange("group_ids") {
-			rawGroups := d.Get("group_ids").([]interface{})
-			for _, v := range rawGroups {
-
-				_, err := awxService.AssociateGroup(id, map[string]interface{}{
-					"id": v.(int),
-				}, map[string]string{})
-				if err != nil {
-					return err
-				}
+		manage := d.Get("manage_groups").(bool)
+		if manage && (d.HasChange("group_ids") || d.HasChange("manage_groups")) {
+			if err := setHostGroups(awx, id, d.Get("group_ids").(*schema.Set)); err != nil {
+				return err
 			}
 		}
 		return resourceHostRead(d, m)
@@ -142,7 +165,7 @@ func resourceHostUpdate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceHostRead(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.HostService
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
@@ -152,12 +175,31 @@ func resourceHostRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
+	if len(res.Results) == 0 {
+		d.SetId("")
+		return nil
+	}
 	d = setHostResourceData(d, res.Results[0])
+	// Imported hosts have no manage_groups yet, they start out managed
+	manage, ok := d.GetOkExists("manage_groups")
+	if !ok {
+		manage = true
+		d.Set("manage_groups", true)
+	}
+	if !manage.(bool) {
+		d.Set("group_ids", nil)
+		return nil
+	}
+	groups, err := getHostGroupIDs(awx, id)
+	if err != nil {
+		return err
+	}
+	d.Set("group_ids", groups)
 	return nil
 }
 
 func resourceHostDelete(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.HostService
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
@@ -176,7 +218,22 @@ func setHostResourceData(d *schema.ResourceData, r *awxgo.Host) *schema.Resource
 	d.Set("inventory_id", r.Inventory)
 	d.Set("enabled", r.Enabled)
 	d.Set("instance_id", r.InstanceID)
-	d.Set("variables", normalizeJSONYaml(r.Variables))
-	d.Set("group_ids", d.Get("group_ids").([]interface{}))
+	setVariables(d, r.Variables)
 	return d
 }
+
+// setHostGroups makes the groups of a host match groups, the current groups
+// are read from AWX so memberships added outside of Terraform are removed too
+func setHostGroups(awx *AWXClient, id int, groups *schema.Set) error {
+	current, err := getHostGroupIDs(awx, id)
+	if err != nil {
+		return err
+	}
+	old := schema.NewSet(schema.HashInt, intsToInterfaces(current))
+	return awx.apiUpdateAssociations(fmt.Sprintf("/api/v2/hosts/%d/groups/", id), old, groups)
+}
+
+// getHostGroupIDs returns the IDs of the groups a host is a direct member of
+func getHostGroupIDs(awx *AWXClient, id int) ([]int, error) {
+	return awx.apiListIDs(fmt.Sprintf("/api/v2/hosts/%d/groups/", id), nil)
+}
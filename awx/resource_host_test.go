@@ -25,6 +25,28 @@ func TestAccAWXHost(t *testing.T) {
 	})
 }
 
+// awx_host group_ids test case, the host leaves its last group in the second step
+func TestAccAWXHostGroups(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { TestAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccHostGroupsConfig, "awx_inventory_group.testacc-hostgrp.id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_host.testacc-hostgrp", "group_ids.#", "1"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccHostGroupsConfig, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_host.testacc-hostgrp", "group_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckStateHost(skey, svalue string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources["awx_host.testacc-host_1"]
@@ -57,3 +79,16 @@ VARIABLES
 
   }
 `
+
+const testAccHostGroupsConfig = `
+resource "awx_inventory_group" "testacc-hostgrp" {
+	name         = "testacc-hostgrp"
+	inventory_id = "1"
+}
+
+resource "awx_host" "testacc-hostgrp" {
+	name         = "testacc-hostgrp"
+	inventory_id = "1"
+	group_ids    = [%s]
+}
+`
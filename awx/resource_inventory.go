@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	awxgo "gitlab.com/dhendel/awx-go"
 )
 
@@ -30,30 +31,61 @@ func resourceInventoryObject() *schema.Resource {
 				Required: true,
 			},
 			"kind": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"", "smart"}, false),
+				Description:  "Either \"\" (regular) or smart, constructed inventories are managed with awx_constructed_inventory",
 			},
 			"host_filter": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validateHostFilter,
+				Description:  "Filter selecting the hosts of a smart inventory",
+			},
+			"host_count": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of hosts in the inventory, for smart inventories the hosts matched by host_filter",
 			},
 			"variables": &schema.Schema{
-				Type:      schema.TypeString,
-				Optional:  true,
-				Default:   "",
-				StateFunc: normalizeJSONYaml,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "",
+				StateFunc:        normalizeJSONYaml,
+				ValidateFunc:     validateVariables,
+				DiffSuppressFunc: suppressEquivalentVariables,
+				ConflictsWith:    []string{"variables_map"},
+			},
+			"variables_map": &schema.Schema{
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				ValidateFunc:     validateVariablesMap,
+				DiffSuppressFunc: suppressEquivalentVariablesMapValue,
+				Description:      "Map of JSON encoded variables, e.g. enabled = jsonencode(false), so values keep their type",
+				ConflictsWith:    []string{"variables"},
 			},
 		},
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+
+		CustomizeDiff: resourceInventoryCustomizeDiff,
 	}
 }
 
+func resourceInventoryCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("host_filter").(string) != "" && d.Get("kind").(string) != "smart" {
+		return fmt.Errorf("host_filter can only be set on inventories of kind smart")
+	}
+	return nil
+}
+
 func resourceInventoryCreate(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.InventoriesService
 
 	_, res, _ := awxService.ListInventories(map[string]string{
@@ -64,13 +96,18 @@ func resourceInventoryCreate(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("Inventory %s with id %d already exists", res.Results[0].Name, res.Results[0].ID)
 	}
 
+	variables, err := getVariables(d)
+	if err != nil {
+		return err
+	}
+
 	result, err := awxService.CreateInventory(map[string]interface{}{
 		"name":         d.Get("name").(string),
 		"organization": d.Get("organization_id").(string),
 		"description":  d.Get("description").(string),
 		"kind":         d.Get("kind").(string),
 		"host_filter":  d.Get("host_filter").(string),
-		"variables":    d.Get("variables").(string),
+		"variables":    variables,
 	}, map[string]string{})
 	if err != nil {
 		return err
@@ -82,13 +119,17 @@ func resourceInventoryCreate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceInventoryUpdate(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.InventoriesService
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
 		return err
 	}
 	_, res, _ := awxService.ListInventories(map[string]string{"id": d.Id()})
+	variables, err := getVariables(d)
+	if err != nil {
+		return err
+	}
 	if len(res.Results) >= 1 {
 
 		_, err = awxService.UpdateInventory(id, map[string]interface{}{
@@ -97,7 +138,7 @@ func resourceInventoryUpdate(d *schema.ResourceData, m interface{}) error {
 			"description":  d.Get("description").(string),
 			"kind":         d.Get("kind").(string),
 			"host_filter":  d.Get("host_filter").(string),
-			"variables":    d.Get("variables").(string),
+			"variables":    variables,
 		}, nil)
 		if err != nil {
 			return err
@@ -111,7 +152,7 @@ func resourceInventoryUpdate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceInventoryRead(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.InventoriesService
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
@@ -126,7 +167,7 @@ func resourceInventoryRead(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceInventoryDelete(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.InventoriesService
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
@@ -144,7 +185,12 @@ func setInventoryResourceData(d *schema.ResourceData, r *awxgo.Inventory) *schem
 	d.Set("organization_id", strconv.Itoa(r.Organization))
 	d.Set("description", r.Description)
 	d.Set("kind", r.Kind)
-	d.Set("host_filter", r.HostFilter)
-	d.Set("variables", normalizeJSONYaml(r.Variables))
+	if hostFilter, ok := r.HostFilter.(string); ok {
+		d.Set("host_filter", hostFilter)
+	} else {
+		d.Set("host_filter", "")
+	}
+	d.Set("host_count", r.TotalHosts)
+	setVariables(d, r.Variables)
 	return d
 }
@@ -31,25 +31,53 @@ func resourceInventoryGroupObject() *schema.Resource {
 				ForceNew: true,
 			},
 			"variables": &schema.Schema{
-				Type:      schema.TypeString,
-				Optional:  true,
-				Default:   "",
-				StateFunc: normalizeJSONYaml,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "",
+				StateFunc:        normalizeJSONYaml,
+				ValidateFunc:     validateVariables,
+				DiffSuppressFunc: suppressEquivalentVariables,
+				ConflictsWith:    []string{"variables_map"},
+			},
+			"variables_map": &schema.Schema{
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				ValidateFunc:     validateVariablesMap,
+				DiffSuppressFunc: suppressEquivalentVariablesMapValue,
+				Description:      "Map of JSON encoded variables, e.g. enabled = jsonencode(false), so values keep their type",
+				ConflictsWith:    []string{"variables"},
 			},
 			"child_group_ids": {
-				Type:     schema.TypeList,
-				Elem:     &schema.Schema{Type: schema.TypeInt},
-				Optional: true,
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Optional:    true,
+				Description: "Authoritative set of child group IDs, children missing from it are removed from the group",
+			},
+			"manage_children": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Manage the child groups with child_group_ids, set to false to leave them alone",
 			},
 		},
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+
+		CustomizeDiff: resourceInventoryGroupCustomizeDiff,
 	}
 }
 
+func resourceInventoryGroupCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if !d.Get("manage_children").(bool) && d.Get("child_group_ids").(*schema.Set).Len() > 0 {
+		return fmt.Errorf("child_group_ids can't be set when manage_children is false")
+	}
+	return nil
+}
+
 func resourceInventoryGroupCreate(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.GroupService
 
 	_, res, _ := awxService.ListGroups(map[string]string{
@@ -60,51 +88,62 @@ func resourceInventoryGroupCreate(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("InventoryGroup %s with id %d already exists", res.Results[0].Name, res.Results[0].ID)
 	}
 
+	variables, err := getVariables(d)
+	if err != nil {
+		return err
+	}
+
 	result, err := awxService.CreateGroup(map[string]interface{}{
 		"name":        d.Get("name").(string),
 		"description": d.Get("description").(string),
 		"inventory":   d.Get("inventory_id").(string),
-		"variables":   d.Get("variables").(string),
+		"variables":   variables,
 	}, map[string]string{})
 	if err != nil {
 		return err
 	}
 
-	if childGroups, ok := d.GetOkExists("child_group_ids"); ok {
-		for _, i := range childGroups.([]interface{}) {
-			_, err := awxService.AddChildGroup(result.ID, i.(int))
-
-			if err != nil {
-				return fmt.Errorf("Failed to add child group %d to group id %d ", i, result.ID)
-			}
+	d.SetId(strconv.Itoa(result.ID))
+	if d.Get("manage_children").(bool) {
+		if err := setGroupChildren(awx, result.ID, d.Get("child_group_ids").(*schema.Set)); err != nil {
+			return err
 		}
 	}
-
-	d.SetId(strconv.Itoa(result.ID))
 	return resourceInventoryGroupRead(d, m)
 
 }
 
 func resourceInventoryGroupUpdate(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.GroupService
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
 		return err
 	}
 	_, res, _ := awxService.ListGroups(map[string]string{"id": d.Id()})
+	variables, err := getVariables(d)
+	if err != nil {
+		return err
+	}
 	if len(res.Results) >= 1 {
 
 		_, err = awxService.UpdateGroup(id, map[string]interface{}{
 			"name":        d.Get("name").(string),
 			"description": d.Get("description").(string),
 			"inventory":   d.Get("inventory_id").(string),
-			"variables":   d.Get("variables").(string),
+			"variables":   variables,
 		}, nil)
 		if err != nil {
 			return err
 		}
 
+		manage := d.Get("manage_children").(bool)
+		if manage && (d.HasChange("child_group_ids") || d.HasChange("manage_children")) {
+			if err := setGroupChildren(awx, id, d.Get("child_group_ids").(*schema.Set)); err != nil {
+				return err
+			}
+		}
+
 		return resourceInventoryGroupRead(d, m)
 	}
 	return fmt.Errorf("Group %s with id %d doesn't exist", d.Get("name").(string), id)
@@ -112,7 +151,7 @@ func resourceInventoryGroupUpdate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceInventoryGroupDelete(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.GroupService
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
@@ -126,27 +165,64 @@ func resourceInventoryGroupDelete(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceInventoryGroupRead(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.GroupService
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
 		return fmt.Errorf("InventoryGroup %d not found", id)
 	}
-	_, res, err := awxService.ListGroups(map[string]string{
-		"name":      d.Get("name").(string),
-		"inventory": d.Get("inventory_id").(string),
-	})
+	_, res, err := awxService.ListGroups(map[string]string{"id": strconv.Itoa(id)})
+	if err != nil {
+		return err
+	}
+	if len(res.Results) == 0 {
+		d.SetId("")
+		return nil
+	}
+	group := res.Results[0]
+	d = setInventoryGroupResourceData(d, group)
+	// Imported groups have no manage_children yet, they start out managed
+	manage, ok := d.GetOkExists("manage_children")
+	if !ok {
+		manage = true
+		d.Set("manage_children", true)
+	}
+	if !manage.(bool) {
+		d.Set("child_group_ids", nil)
+		return nil
+	}
+	childrenURL := groupChildrenEndpoint(id)
+	if group.Related != nil && group.Related.Children != "" {
+		childrenURL = group.Related.Children
+	}
+	children, err := awx.apiListIDs(childrenURL, nil)
 	if err != nil {
 		return err
 	}
-	d = setInventoryGroupResourceData(d, res.Results[0])
+	d.Set("child_group_ids", children)
 	return nil
 }
 
 func setInventoryGroupResourceData(d *schema.ResourceData, r *awxgo.Group) *schema.ResourceData {
 	d.Set("name", r.Name)
 	d.Set("description", r.Description)
-	d.Set("inventory_id", r.Inventory)
-	d.Set("variables", normalizeJSONYaml(r.Variables))
+	d.Set("inventory_id", strconv.Itoa(r.Inventory))
+	setVariables(d, r.Variables)
 	return d
 }
+
+func groupChildrenEndpoint(groupID int) string {
+	return fmt.Sprintf("/api/v2/groups/%d/children/", groupID)
+}
+
+// setGroupChildren makes the children of a group match children. The current
+// children are read from AWX, so every child missing from the set is removed,
+// removed children are only disassociated and the groups themselves are kept.
+func setGroupChildren(awx *AWXClient, groupID int, children *schema.Set) error {
+	current, err := awx.apiListIDs(groupChildrenEndpoint(groupID), nil)
+	if err != nil {
+		return err
+	}
+	old := schema.NewSet(schema.HashInt, intsToInterfaces(current))
+	return awx.apiUpdateAssociations(groupChildrenEndpoint(groupID), old, children)
+}
@@ -58,3 +58,53 @@ resource "awx_inventory_group" "testacc-grp" {
 	description = "AWX Acc test group"
 }
 `
+
+// awx_inventory_group nested hierarchy, etcd is moved from k8s to all in the second step
+func TestAccAWXInventoryGroupChildren(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { TestAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccInventoryGroupChildrenConfig,
+					"awx_inventory_group.k8s.id", "awx_inventory_group.etcd.id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_inventory_group.all", "child_group_ids.#", "1"),
+					resource.TestCheckResourceAttr("awx_inventory_group.k8s", "child_group_ids.#", "1"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccInventoryGroupChildrenConfig,
+					"awx_inventory_group.k8s.id, awx_inventory_group.etcd.id", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_inventory_group.all", "child_group_ids.#", "2"),
+					resource.TestCheckResourceAttr("awx_inventory_group.k8s", "child_group_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccInventoryGroupChildrenConfig = `
+resource "awx_inventory" "testacc" {
+	name = "testacc-grp-children"
+	organization_id = 1
+}
+
+resource "awx_inventory_group" "etcd" {
+	name = "etcd"
+	inventory_id = "${awx_inventory.testacc.id}"
+}
+
+resource "awx_inventory_group" "all" {
+	name = "all-nodes"
+	inventory_id = "${awx_inventory.testacc.id}"
+	child_group_ids = [%s]
+}
+
+resource "awx_inventory_group" "k8s" {
+	name = "k8s"
+	inventory_id = "${awx_inventory.testacc.id}"
+	child_group_ids = [%s]
+}
+`
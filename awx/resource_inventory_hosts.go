@@ -0,0 +1,469 @@
+package awx
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	awxgo "gitlab.com/dhendel/awx-go"
+)
+
+// resourceInventoryHostsObject manages every host of an inventory from a single
+// map. Each value is a JSON document, usually built with jsonencode(), holding
+// the variables, enabled flag and group IDs of the host.
+func resourceInventoryHostsObject() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceInventoryHostsCreate,
+		Read:   resourceInventoryHostsRead,
+		Update: resourceInventoryHostsUpdate,
+		Delete: resourceInventoryHostsDelete,
+
+		Schema: map[string]*schema.Schema{
+			"inventory_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"hosts": &schema.Schema{
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				ValidateFunc:     validateInventoryHosts,
+				DiffSuppressFunc: suppressEquivalentInventoryHost,
+				Description:      "Authoritative map of host name to jsonencode({variables, enabled, groups}), hosts of the inventory missing from the map are deleted",
+			},
+			"parallelism": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     10,
+				Description: "Number of concurrent API calls used to apply host changes",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+	}
+}
+
+// inventoryHost is the normalized form of a hosts entry
+type inventoryHost struct {
+	Enabled   bool                   `json:"enabled"`
+	Groups    []int                  `json:"groups"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+// inventoryHostState is a host of the inventory as found in AWX
+type inventoryHostState struct {
+	ID int
+	inventoryHost
+}
+
+func resourceInventoryHostsCreate(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	inv := d.Get("inventory_id").(int)
+	if _, err := awx.InventoriesService.GetInventory(inv, map[string]string{}); err != nil {
+		return fmt.Errorf("Inventory %d not found: %s", inv, err)
+	}
+
+	created, err := applyInventoryHosts(awx, d, inv)
+	if err != nil {
+		// A failed create would leave a tainted resource whose replacement
+		// deletes every host of the inventory, so the hosts created here are
+		// deleted again and the resource is not stored
+		return rollbackInventoryHosts(awx, created, d.Get("parallelism").(int), err)
+	}
+	d.SetId(strconv.Itoa(inv))
+	return resourceInventoryHostsRead(d, m)
+}
+
+func resourceInventoryHostsUpdate(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	inv := d.Get("inventory_id").(int)
+	if _, err := applyInventoryHosts(awx, d, inv); err != nil {
+		return inventoryHostsApplyError(d, m, err)
+	}
+	return resourceInventoryHostsRead(d, m)
+}
+
+// rollbackInventoryHosts deletes the hosts created by a failed create
+func rollbackInventoryHosts(awx *AWXClient, created map[string]int, parallelism int, err error) error {
+	var ops []func() error
+	for name, id := range created {
+		name, id := name, id
+		ops = append(ops, func() error {
+			if err := awx.apiDelete(fmt.Sprintf("/api/v2/hosts/%d/", id)); err != nil && !isAPINotFound(err) {
+				return fmt.Errorf("Failed to delete host %s: %s", name, err)
+			}
+			return nil
+		})
+	}
+	if rollbackErr := runConcurrently(ops, parallelism); rollbackErr != nil {
+		return fmt.Errorf("%s, deleting the hosts created meanwhile failed too: %s", err, rollbackErr)
+	}
+	return err
+}
+
+// inventoryHostsApplyError refreshes the state after a partially applied
+// change, so the next plan starts from the hosts which were actually changed
+func inventoryHostsApplyError(d *schema.ResourceData, m interface{}, err error) error {
+	if readErr := resourceInventoryHostsRead(d, m); readErr != nil {
+		return fmt.Errorf("%s, refreshing the hosts afterwards failed too, the state may be stale: %s", err, readErr)
+	}
+	return err
+}
+
+func resourceInventoryHostsRead(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	inv, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Invalid inventory ID %s", d.Id())
+	}
+	current, err := listInventoryHosts(awx, inv)
+	if err != nil {
+		if isAPINotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	hosts := make(map[string]string, len(current))
+	for name, h := range current {
+		b, err := json.Marshal(h.inventoryHost)
+		if err != nil {
+			return err
+		}
+		hosts[name] = string(b)
+	}
+	d.Set("inventory_id", inv)
+	d.Set("hosts", hosts)
+	return nil
+}
+
+func resourceInventoryHostsDelete(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	inv := d.Get("inventory_id").(int)
+	current, err := listInventoryHosts(awx, inv)
+	if err != nil {
+		if isAPINotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	var ops []func() error
+	for name, h := range current {
+		name, id := name, h.ID
+		ops = append(ops, func() error {
+			if err := awx.apiDelete(fmt.Sprintf("/api/v2/hosts/%d/", id)); err != nil {
+				return fmt.Errorf("Failed to delete host %s: %s", name, err)
+			}
+			return nil
+		})
+	}
+	if err := runConcurrently(ops, d.Get("parallelism").(int)); err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+// applyInventoryHosts diffs the configured hosts against the inventory and
+// creates, updates and deletes hosts with bounded concurrency. It returns the
+// IDs of the hosts it created by name, also when some operations failed.
+func applyInventoryHosts(awx *AWXClient, d *schema.ResourceData, inv int) (map[string]int, error) {
+	current, err := listInventoryHosts(awx, inv)
+	if err != nil {
+		return nil, err
+	}
+	desired := make(map[string]*inventoryHost)
+	for name, v := range d.Get("hosts").(map[string]interface{}) {
+		h, err := parseInventoryHost(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid host %s: %s", name, err)
+		}
+		desired[name] = h
+	}
+
+	created := make(map[string]int)
+	var mu sync.Mutex
+	var ops []func() error
+	for name, want := range desired {
+		name, want := name, want
+		have, ok := current[name]
+		if !ok {
+			ops = append(ops, func() error {
+				id, err := createInventoryHost(awx, inv, name, want)
+				if id != 0 {
+					mu.Lock()
+					created[name] = id
+					mu.Unlock()
+				}
+				return err
+			})
+		} else if !reflect.DeepEqual(have.inventoryHost, *want) {
+			ops = append(ops, func() error { return updateInventoryHost(awx, name, have, want) })
+		}
+	}
+	for name, have := range current {
+		if _, ok := desired[name]; ok {
+			continue
+		}
+		name, id := name, have.ID
+		ops = append(ops, func() error {
+			if err := awx.apiDelete(fmt.Sprintf("/api/v2/hosts/%d/", id)); err != nil {
+				return fmt.Errorf("Failed to delete host %s: %s", name, err)
+			}
+			return nil
+		})
+	}
+	err = runConcurrently(ops, d.Get("parallelism").(int))
+	return created, err
+}
+
+// createInventoryHost returns the ID of the new host, also when adding it to
+// its groups failed
+func createInventoryHost(awx *AWXClient, inv int, name string, h *inventoryHost) (int, error) {
+	vars, err := inventoryHostVariables(h)
+	if err != nil {
+		return 0, err
+	}
+	result := new(awxgo.Host)
+	err = awx.apiPost("/api/v2/hosts/", map[string]interface{}{
+		"name":      name,
+		"inventory": inv,
+		"enabled":   h.Enabled,
+		"variables": vars,
+	}, result)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to create host %s: %s", name, err)
+	}
+	for _, g := range h.Groups {
+		if err := setHostGroup(awx, result.ID, g, true); err != nil {
+			return result.ID, fmt.Errorf("Failed to add host %s to group %d: %s", name, g, err)
+		}
+	}
+	return result.ID, nil
+}
+
+func updateInventoryHost(awx *AWXClient, name string, have *inventoryHostState, want *inventoryHost) error {
+	if have.Enabled != want.Enabled || !reflect.DeepEqual(have.Variables, want.Variables) {
+		vars, err := inventoryHostVariables(want)
+		if err != nil {
+			return err
+		}
+		err = awx.apiPatch(fmt.Sprintf("/api/v2/hosts/%d/", have.ID), map[string]interface{}{
+			"enabled":   want.Enabled,
+			"variables": vars,
+		}, nil)
+		if err != nil {
+			return fmt.Errorf("Failed to update host %s: %s", name, err)
+		}
+	}
+	oldGroups, newGroups := intSet(have.Groups), intSet(want.Groups)
+	for g := range oldGroups {
+		if !newGroups[g] {
+			if err := setHostGroup(awx, have.ID, g, false); err != nil {
+				return fmt.Errorf("Failed to remove host %s from group %d: %s", name, g, err)
+			}
+		}
+	}
+	for g := range newGroups {
+		if !oldGroups[g] {
+			if err := setHostGroup(awx, have.ID, g, true); err != nil {
+				return fmt.Errorf("Failed to add host %s to group %d: %s", name, g, err)
+			}
+		}
+	}
+	return nil
+}
+
+// setHostGroup associates or disassociates a host and a group without going
+// through awx-go, whose services are not safe for concurrent use
+func setHostGroup(awx *AWXClient, hostID, groupID int, associate bool) error {
+	data := map[string]interface{}{"id": groupID}
+	if !associate {
+		data["disassociate"] = true
+	}
+	return awx.apiPost(fmt.Sprintf("/api/v2/hosts/%d/groups/", hostID), data, nil)
+}
+
+// listInventoryHosts returns every host of an inventory keyed by name, group
+// memberships are collected per group to avoid one request per host
+func listInventoryHosts(awx *AWXClient, inv int) (map[string]*inventoryHostState, error) {
+	hosts := make(map[string]*inventoryHostState)
+	byID := make(map[int]*inventoryHostState)
+	err := awx.apiList(fmt.Sprintf("/api/v2/inventories/%d/hosts/", inv), nil, func(raw json.RawMessage) error {
+		r := new(awxgo.Host)
+		if err := json.Unmarshal(raw, r); err != nil {
+			return err
+		}
+		vars, err := parseVariables(r.Variables)
+		if err != nil {
+			return fmt.Errorf("Host %s has invalid variables: %s", r.Name, err)
+		}
+		h := &inventoryHostState{
+			ID: r.ID,
+			inventoryHost: inventoryHost{
+				Enabled:   r.Enabled,
+				Groups:    []int{},
+				Variables: vars,
+			},
+		}
+		hosts[r.Name] = h
+		byID[r.ID] = h
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := awx.apiListIDs(fmt.Sprintf("/api/v2/inventories/%d/groups/", inv), nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range groups {
+		members, err := awx.apiListIDs(fmt.Sprintf("/api/v2/groups/%d/hosts/", g), nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range members {
+			if h, ok := byID[id]; ok {
+				h.Groups = append(h.Groups, g)
+			}
+		}
+	}
+	for _, h := range hosts {
+		sort.Ints(h.Groups)
+	}
+	return hosts, nil
+}
+
+// parseInventoryHost decodes a hosts entry, enabled defaults to true and
+// variables may be given either as an object or as a JSON/YAML string
+func parseInventoryHost(s string) (*inventoryHost, error) {
+	raw := struct {
+		Enabled   *bool       `json:"enabled"`
+		Groups    []int       `json:"groups"`
+		Variables interface{} `json:"variables"`
+	}{}
+	if strings.TrimSpace(s) != "" {
+		if err := json.Unmarshal([]byte(s), &raw); err != nil {
+			return nil, err
+		}
+	}
+	h := &inventoryHost{
+		Enabled:   true,
+		Groups:    []int{},
+		Variables: map[string]interface{}{},
+	}
+	if raw.Enabled != nil {
+		h.Enabled = *raw.Enabled
+	}
+	if raw.Groups != nil {
+		h.Groups = raw.Groups
+	}
+	sort.Ints(h.Groups)
+	switch v := raw.Variables.(type) {
+	case nil:
+	case string:
+		vars, err := parseVariables(v)
+		if err != nil {
+			return nil, fmt.Errorf("variables %s", err)
+		}
+		h.Variables = vars
+	case map[string]interface{}:
+		h.Variables = v
+	default:
+		return nil, fmt.Errorf("variables must be an object or a JSON/YAML string")
+	}
+	return h, nil
+}
+
+func inventoryHostVariables(h *inventoryHost) (string, error) {
+	if len(h.Variables) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(h.Variables)
+	return string(b), err
+}
+
+func validateInventoryHosts(v interface{}, k string) (ws []string, errors []error) {
+	for name, h := range v.(map[string]interface{}) {
+		s, ok := h.(string)
+		if !ok {
+			// Unknown until apply
+			continue
+		}
+		if _, err := parseInventoryHost(s); err != nil {
+			errors = append(errors, fmt.Errorf("%q: host %s is not valid: %s", k, name, err))
+		}
+	}
+	return
+}
+
+func suppressEquivalentInventoryHost(k, old, new string, d *schema.ResourceData) bool {
+	// Hosts being added or removed, and the map length diffed as hosts.%
+	if old == "" || new == "" || strings.HasSuffix(k, ".%") {
+		return false
+	}
+	o, err := parseInventoryHost(old)
+	if err != nil {
+		return false
+	}
+	n, err := parseInventoryHost(new)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(o, n)
+}
+
+func intSet(ids []int) map[int]bool {
+	set := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+// runConcurrently runs ops with at most parallelism of them in flight and
+// reports every failure
+func runConcurrently(ops []func() error, parallelism int) error {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []string
+	for _, op := range ops {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(op func() error) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := op(); err != nil {
+				mu.Lock()
+				errs = append(errs, err.Error())
+				mu.Unlock()
+			}
+		}(op)
+	}
+	wg.Wait()
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("%d of %d host operations failed:\n%s", len(errs), len(ops), strings.Join(errs, "\n"))
+	}
+	return nil
+}
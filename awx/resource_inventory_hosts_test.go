@@ -0,0 +1,138 @@
+package awx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// TestInventoryHostsCreateRollback fails one of two host creations against a
+// fake AWX and expects the other host to be deleted and no ID to be set
+func TestInventoryHostsCreateRollback(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v2/inventories/1/":
+			w.Write([]byte(`{"id": 1, "name": "testacc"}`))
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/api/v2/inventories/1/"):
+			w.Write([]byte(`{"count": 0, "next": null, "results": []}`))
+		case r.Method == "POST" && r.URL.Path == "/api/v2/hosts/":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["name"] == "bad" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"name": ["invalid"]}`))
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": 10, "name": "good"}`))
+		case r.Method == "DELETE":
+			mu.Lock()
+			deleted = append(deleted, r.URL.Path)
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	awx := (&Config{Endpoint: server.URL}).Client()
+	d := schema.TestResourceDataRaw(t, resourceInventoryHostsObject().Schema, map[string]interface{}{
+		"inventory_id": 1,
+		"hosts":        map[string]interface{}{"good": "{}", "bad": "{}"},
+	})
+	if err := resourceInventoryHostsCreate(d, awx); err == nil {
+		t.Fatalf("resourceInventoryHostsCreate() didn't fail")
+	}
+	if d.Id() != "" {
+		t.Errorf("ID = %q after a failed create, want none", d.Id())
+	}
+	if len(deleted) != 1 || deleted[0] != "/api/v2/hosts/10/" {
+		t.Errorf("deleted %v, want the created host /api/v2/hosts/10/", deleted)
+	}
+}
+
+// awx_inventory_hosts test case, the second step renames, regroups and removes hosts
+func TestAccAWXInventoryHosts(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { TestAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInventoryHostsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_inventory_hosts.testacc", "hosts.%", "3"),
+				),
+			},
+			{
+				Config: testAccInventoryHostsUpdatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_inventory_hosts.testacc", "hosts.%", "2"),
+				),
+			},
+			{
+				ResourceName:            "awx_inventory_hosts.testacc",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"parallelism"},
+			},
+		},
+	})
+}
+
+const testAccInventoryHostsConfig = `
+resource "awx_inventory" "testacc" {
+	name = "testacc-bulk-hosts"
+	organization_id = 1
+}
+
+resource "awx_inventory_group" "web" {
+	name = "web"
+	inventory_id = "${awx_inventory.testacc.id}"
+}
+
+resource "awx_inventory_hosts" "testacc" {
+	inventory_id = awx_inventory.testacc.id
+	hosts = {
+		for i in range(3) : "web-${i}.awx.local" => jsonencode({
+			groups    = [awx_inventory_group.web.id]
+			variables = { ansible_host = "10.0.0.${i}" }
+		})
+	}
+}
+`
+
+const testAccInventoryHostsUpdatedConfig = `
+resource "awx_inventory" "testacc" {
+	name = "testacc-bulk-hosts"
+	organization_id = 1
+}
+
+resource "awx_inventory_group" "web" {
+	name = "web"
+	inventory_id = "${awx_inventory.testacc.id}"
+}
+
+resource "awx_inventory_hosts" "testacc" {
+	inventory_id = awx_inventory.testacc.id
+	hosts = {
+		"web-0.awx.local" = jsonencode({
+			enabled   = false
+			variables = "ansible_host: 10.0.0.100"
+		})
+		"web-1.awx.local" = jsonencode({
+			groups = [awx_inventory_group.web.id]
+		})
+	}
+}
+`
@@ -58,3 +58,30 @@ resource "awx_inventory" "testacc" {
 	description = "AWX Acc test"
 }
 `
+
+// awx_inventory smart inventory test case
+func TestAccAWXInventorySmart(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { TestAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInventorySmartConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_inventory.smart", "kind", "smart"),
+					resource.TestCheckResourceAttr("awx_inventory.smart", "host_filter", "name__startswith=testacc"),
+					resource.TestCheckResourceAttrSet("awx_inventory.smart", "host_count"),
+				),
+			},
+		},
+	})
+}
+
+const testAccInventorySmartConfig = `
+resource "awx_inventory" "smart" {
+	name = "testacc-smart"
+	organization_id = 1
+	kind = "smart"
+	host_filter = "name__startswith=testacc"
+}
+`
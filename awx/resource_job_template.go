@@ -181,9 +181,15 @@ func resourceJobTemplateObject() *schema.Resource {
 				Default:  false,
 			},
 			"custom_virtualenv": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Path of the custom virtualenv, only used by AWX before 18.0, later servers ignore it and run jobs in execution_environment_id",
+			},
+			"execution_environment_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Execution environment of the jobs, unset uses the project or organization default",
 			},
 			"ask_job_type_on_launch": &schema.Schema{
 				Type:     schema.TypeBool,
@@ -206,7 +212,7 @@ func resourceJobTemplateObject() *schema.Resource {
 }
 
 func resourceJobTemplateCreate(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.JobTemplateService
 	var jobID int
 	var finished time.Time
@@ -277,7 +283,8 @@ func resourceJobTemplateCreate(d *schema.ResourceData, m interface{}) error {
 		"become_enabled":           d.Get("become_enabled").(bool),
 		"diff_mode":                d.Get("diff_mode").(bool),
 		"allow_simultaneous":       d.Get("allow_simultaneous").(bool),
-		"custom_virtualenv":        AtoipOr(d.Get("custom_virtualenv").(string), nil),
+		"custom_virtualenv":        stringOrNil(d.Get("custom_virtualenv").(string)),
+		"execution_environment":    optionalID(d, "execution_environment_id"),
 		"credential":               AtoipOr(d.Get("credential_id").(string), nil),
 		"vault_credential":         AtoipOr(d.Get("vault_credential_id").(string), nil),
 	}
@@ -302,7 +309,7 @@ func resourceJobTemplateCreate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceJobTemplateUpdate(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.JobTemplateService
 	_, res, err := awxService.ListJobTemplates(map[string]string{
 		"id":      d.Id(),
@@ -350,7 +357,8 @@ func resourceJobTemplateUpdate(d *schema.ResourceData, m interface{}) error {
 		"become_enabled":           d.Get("become_enabled").(bool),
 		"diff_mode":                d.Get("diff_mode").(bool),
 		"allow_simultaneous":       d.Get("allow_simultaneous").(bool),
-		"custom_virtualenv":        AtoipOr(d.Get("custom_virtualenv").(string), nil),
+		"custom_virtualenv":        stringOrNil(d.Get("custom_virtualenv").(string)),
+		"execution_environment":    optionalID(d, "execution_environment_id"),
 		"credential":               AtoipOr(d.Get("credential_id").(string), nil),
 		"vault_credential":         AtoipOr(d.Get("vault_credential_id").(string), nil),
 	}, map[string]string{})
@@ -372,7 +380,7 @@ func resourceJobTemplateUpdate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceJobTemplateRead(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.JobTemplateService
 	_, res, err := awxService.ListJobTemplates(map[string]string{
 		"id": strconv.Itoa(d.Get("job_id").(int)),
@@ -386,11 +394,16 @@ func resourceJobTemplateRead(d *schema.ResourceData, m interface{}) error {
 		return nil
 	}
 	d = setJobTemplateResourceData(d, res.Results[0])
+	env, err := getExecutionEnvironmentFields(awx, fmt.Sprintf("/api/v2/job_templates/%d/", res.Results[0].ID))
+	if err != nil {
+		return err
+	}
+	d.Set("execution_environment_id", intOrZero(env.ExecutionEnvironment))
 	return nil
 }
 
 func resourceJobTemplateDelete(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.JobTemplateService
 	_, res, err := awxService.ListJobTemplates(map[string]string{
 		"id":      d.Id(),
@@ -453,7 +466,7 @@ func setJobTemplateResourceData(d *schema.ResourceData, r *awxgo.JobTemplate) *s
 }
 
 func importJobTemplateData(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.JobTemplateService
 
 	id, err := strconv.Atoi(d.Id())
@@ -22,6 +22,15 @@ func TestAccAWXJobTemplate(t *testing.T) {
 					testAccCheckStateJobTemplate("job_type", "run"),
 					testAccCheckStateJobTemplate("inventory_id", "1"),
 					testAccCheckStateJobTemplate("playbook", "hello_world.yml"),
+					testAccCheckStateJobTemplate("custom_virtualenv", ""),
+				),
+			},
+			{
+				Config: testAccJobTemplateConfig + testAccJobTemplateEnvironmentConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStateJobTemplate("custom_virtualenv", ""),
+					resource.TestCheckResourceAttrPair("awx_job_template.ee", "execution_environment_id",
+						"awx_execution_environment.testacc-jt", "id"),
 				),
 			},
 		},
@@ -67,3 +76,19 @@ resource "awx_job_template" "alpha" {
 	playbook     = "hello_world.yml"
 }
 `
+
+const testAccJobTemplateEnvironmentConfig = `
+resource "awx_execution_environment" "testacc-jt" {
+	name = "testacc-jt"
+	image = "quay.io/ansible/awx-ee:latest"
+}
+
+resource "awx_job_template" "ee" {
+	name                     = "ee"
+	project_id               = "${awx_project.testacc-prj_1.id}"
+	job_type                 = "run"
+	inventory_id             = "1"
+	playbook                 = "hello_world.yml"
+	execution_environment_id = "${awx_execution_environment.testacc-jt.id}"
+}
+`
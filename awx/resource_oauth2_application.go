@@ -0,0 +1,178 @@
+package awx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceOAuth2ApplicationObject() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceOAuth2ApplicationCreate,
+		Read:   resourceOAuth2ApplicationRead,
+		Delete: resourceOAuth2ApplicationDelete,
+		Update: resourceOAuth2ApplicationUpdate,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"organization_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"client_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"confidential", "public"}, false),
+				Description:  "confidential or public, can't be changed after creation",
+			},
+			"authorization_grant_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"authorization-code", "password"}, false),
+				Description:  "authorization-code or password, can't be changed after creation",
+			},
+			"redirect_uris": &schema.Schema{
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Allowed URIs to redirect to, required by the authorization-code grant",
+			},
+			"skip_authorization": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"client_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"client_secret": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Secret of confidential applications, AWX only returns it on creation",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(1 * time.Minute),
+		},
+	}
+}
+
+// oauth2Application represents the awx api application, awx-go doesn't implement it
+type oauth2Application struct {
+	ID                     int    `json:"id"`
+	Name                   string `json:"name"`
+	Description            string `json:"description"`
+	Organization           int    `json:"organization"`
+	ClientType             string `json:"client_type"`
+	AuthorizationGrantType string `json:"authorization_grant_type"`
+	RedirectURIs           string `json:"redirect_uris"`
+	SkipAuthorization      bool   `json:"skip_authorization"`
+	ClientID               string `json:"client_id"`
+	ClientSecret           string `json:"client_secret"`
+}
+
+func resourceOAuth2ApplicationCreate(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	payload := oauth2ApplicationPayload(d)
+	payload["client_type"] = d.Get("client_type").(string)
+	payload["authorization_grant_type"] = d.Get("authorization_grant_type").(string)
+	result := new(oauth2Application)
+	if err := awx.apiPost("/api/v2/applications/", payload, result); err != nil {
+		return err
+	}
+	d.SetId(strconv.Itoa(result.ID))
+	// The secret is only returned once, Read never overwrites it
+	d.Set("client_secret", result.ClientSecret)
+	return resourceOAuth2ApplicationRead(d, m)
+}
+
+func resourceOAuth2ApplicationUpdate(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+	if err := awx.apiPatch(fmt.Sprintf("/api/v2/applications/%d/", id), oauth2ApplicationPayload(d), nil); err != nil {
+		return err
+	}
+	return resourceOAuth2ApplicationRead(d, m)
+}
+
+func resourceOAuth2ApplicationRead(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("OAuth2 application %s not found", d.Id())
+	}
+	r := new(oauth2Application)
+	if err := awx.apiGet(fmt.Sprintf("/api/v2/applications/%d/", id), r, nil); err != nil {
+		if isAPINotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	d = setOAuth2ApplicationResourceData(d, r)
+	return nil
+}
+
+func resourceOAuth2ApplicationDelete(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+	if err := awx.apiDelete(fmt.Sprintf("/api/v2/applications/%d/", id)); err != nil && !isAPINotFound(err) {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+func oauth2ApplicationPayload(d *schema.ResourceData) map[string]interface{} {
+	var uris []string
+	for _, u := range d.Get("redirect_uris").([]interface{}) {
+		uris = append(uris, u.(string))
+	}
+	return map[string]interface{}{
+		"name":               d.Get("name").(string),
+		"description":        d.Get("description").(string),
+		"organization":       d.Get("organization_id").(int),
+		"redirect_uris":      strings.Join(uris, " "),
+		"skip_authorization": d.Get("skip_authorization").(bool),
+	}
+}
+
+func setOAuth2ApplicationResourceData(d *schema.ResourceData, r *oauth2Application) *schema.ResourceData {
+	d.Set("name", r.Name)
+	d.Set("description", r.Description)
+	d.Set("organization_id", r.Organization)
+	d.Set("client_type", r.ClientType)
+	d.Set("authorization_grant_type", r.AuthorizationGrantType)
+	d.Set("redirect_uris", strings.Fields(r.RedirectURIs))
+	d.Set("skip_authorization", r.SkipAuthorization)
+	d.Set("client_id", r.ClientID)
+	return d
+}
@@ -0,0 +1,52 @@
+package awx
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+// awx_oauth2_application and awx_oauth2_token test case
+func TestAccAWXOAuth2Application(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { TestAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOAuth2ApplicationConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("awx_oauth2_application.testacc-ci", "client_id"),
+					resource.TestCheckResourceAttrSet("awx_oauth2_application.testacc-ci", "client_secret"),
+					resource.TestCheckResourceAttr("awx_oauth2_application.testacc-ci", "redirect_uris.#", "2"),
+					resource.TestCheckResourceAttrSet("awx_oauth2_token.testacc-ci", "token"),
+					resource.TestCheckResourceAttrSet("awx_oauth2_token.testacc-ci", "expires"),
+				),
+			},
+			{
+				ResourceName:            "awx_oauth2_application.testacc-ci",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"client_secret"},
+			},
+		},
+	})
+}
+
+const testAccOAuth2ApplicationConfig = `
+resource "awx_oauth2_application" "testacc-ci" {
+	name = "testacc-ci"
+	organization_id = 1
+	client_type = "confidential"
+	authorization_grant_type = "authorization-code"
+	redirect_uris = ["https://ci.test.td/callback", "https://ci2.test.td/callback"]
+}
+
+resource "awx_oauth2_token" "testacc-ci" {
+	application_id = "${awx_oauth2_application.testacc-ci.id}"
+	description = "CI pipeline"
+	scope = "read"
+	rotation = {
+		month = "2019-10"
+	}
+}
+`
@@ -0,0 +1,182 @@
+package awx
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// resourceOAuth2TokenObject creates a token of the provider user, for an
+// application or as personal access token when application_id is unset
+func resourceOAuth2TokenObject() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceOAuth2TokenCreate,
+		Read:   resourceOAuth2TokenRead,
+		Delete: resourceOAuth2TokenDelete,
+		Update: resourceOAuth2TokenUpdate,
+
+		Schema: map[string]*schema.Schema{
+			"application_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Application of the token, a personal access token is created when unset",
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"scope": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "write",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"read", "write"}, false),
+			},
+			"rotation": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that replace the token with a fresh one when changed",
+			},
+			"token": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The access token, AWX only returns it on creation",
+			},
+			"refresh_token": &schema.Schema{
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"expires": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiration time of the token, the plan replaces an expired token",
+			},
+		},
+
+		CustomizeDiff: resourceOAuth2TokenCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(1 * time.Minute),
+		},
+	}
+}
+
+// oauth2Token represents the awx api token, awx-go doesn't implement it
+type oauth2Token struct {
+	ID           int    `json:"id"`
+	Description  string `json:"description"`
+	Application  *int   `json:"application"`
+	Scope        string `json:"scope"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	Expires      string `json:"expires"`
+}
+
+// resourceOAuth2TokenCustomizeDiff replaces expired tokens, Delete removes
+// the expired one from AWX before the new one is created
+func resourceOAuth2TokenCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !oauth2TokenExpired(d.Get("expires").(string), time.Now()) {
+		return nil
+	}
+	log.Printf("[INFO] OAuth2 token %s expired at %s, it will be created again", d.Id(), d.Get("expires").(string))
+	if err := d.SetNewComputed("expires"); err != nil {
+		return err
+	}
+	return d.ForceNew("expires")
+}
+
+func oauth2TokenExpired(expires string, now time.Time) bool {
+	t, err := time.Parse(time.RFC3339, expires)
+	return err == nil && t.Before(now)
+}
+
+func resourceOAuth2TokenCreate(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	payload := map[string]interface{}{
+		"description": d.Get("description").(string),
+		"scope":       d.Get("scope").(string),
+		"application": nil,
+	}
+	if app, ok := d.GetOk("application_id"); ok {
+		payload["application"] = app.(int)
+	}
+	result := new(oauth2Token)
+	if err := awx.apiPost("/api/v2/tokens/", payload, result); err != nil {
+		return err
+	}
+	d.SetId(strconv.Itoa(result.ID))
+	// The token values are only returned once, Read never overwrites them
+	d.Set("token", result.Token)
+	d.Set("refresh_token", result.RefreshToken)
+	return resourceOAuth2TokenRead(d, m)
+}
+
+func resourceOAuth2TokenUpdate(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+	err = awx.apiPatch(fmt.Sprintf("/api/v2/tokens/%d/", id), map[string]interface{}{
+		"description": d.Get("description").(string),
+	}, nil)
+	if err != nil {
+		return err
+	}
+	return resourceOAuth2TokenRead(d, m)
+}
+
+func resourceOAuth2TokenRead(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("OAuth2 token %s not found", d.Id())
+	}
+	r := new(oauth2Token)
+	if err := awx.apiGet(fmt.Sprintf("/api/v2/tokens/%d/", id), r, nil); err != nil {
+		if isAPINotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	d = setOAuth2TokenResourceData(d, r)
+	return nil
+}
+
+func resourceOAuth2TokenDelete(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+	if err := awx.apiDelete(fmt.Sprintf("/api/v2/tokens/%d/", id)); err != nil && !isAPINotFound(err) {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+func setOAuth2TokenResourceData(d *schema.ResourceData, r *oauth2Token) *schema.ResourceData {
+	d.Set("description", r.Description)
+	d.Set("scope", r.Scope)
+	if r.Application != nil {
+		d.Set("application_id", *r.Application)
+	} else {
+		d.Set("application_id", 0)
+	}
+	d.Set("expires", r.Expires)
+	return d
+}
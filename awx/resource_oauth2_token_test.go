@@ -0,0 +1,104 @@
+package awx
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestOAuth2TokenSensitive(t *testing.T) {
+	s := resourceOAuth2TokenObject().Schema
+	for _, attr := range []string{"token", "refresh_token"} {
+		if !s[attr].Sensitive {
+			t.Errorf("%s must be sensitive", attr)
+		}
+	}
+}
+
+// TestOAuth2TokenExpiredDiff plans a token that expired, it is replaced
+func TestOAuth2TokenExpiredDiff(t *testing.T) {
+	for expires, replaced := range map[string]bool{
+		time.Now().Add(-time.Hour).Format(time.RFC3339): true,
+		time.Now().Add(time.Hour).Format(time.RFC3339):  false,
+	} {
+		state := &terraform.InstanceState{
+			ID: "12",
+			Attributes: map[string]string{
+				"id":          "12",
+				"description": "",
+				"scope":       "write",
+				"expires":     expires,
+			},
+		}
+		raw := map[string]interface{}{}
+		diff, err := resourceOAuth2TokenObject().Diff(state, &terraform.ResourceConfig{Raw: raw, Config: raw}, nil)
+		if err != nil {
+			t.Fatalf("Diff() with expires %s: %s", expires, err)
+		}
+		if got := diff != nil && diff.RequiresNew(); got != replaced {
+			t.Errorf("Diff() with expires %s: replaced = %t, want %t", expires, got, replaced)
+		}
+	}
+}
+
+// awx_oauth2_token test case, a personal access token replaced on rotation
+func TestAccAWXOAuth2Token(t *testing.T) {
+	var id string
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { TestAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOAuth2TokenConfig("2019-10"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOAuth2TokenID(&id, false),
+					resource.TestCheckResourceAttrSet("awx_oauth2_token.testacc-pat", "token"),
+					// Personal access tokens have no application to refresh them with
+					resource.TestCheckResourceAttr("awx_oauth2_token.testacc-pat", "refresh_token", ""),
+					resource.TestCheckResourceAttr("awx_oauth2_token.testacc-pat", "application_id", "0"),
+					resource.TestCheckResourceAttr("awx_oauth2_token.testacc-pat", "scope", "write"),
+				),
+			},
+			{
+				Config: testAccOAuth2TokenConfig("2019-11"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckOAuth2TokenID(&id, true),
+					resource.TestCheckResourceAttrSet("awx_oauth2_token.testacc-pat", "token"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckOAuth2TokenID stores the token ID, with replaced set it fails
+// unless the ID differs from the stored one
+func testAccCheckOAuth2TokenID(id *string, replaced bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources["awx_oauth2_token.testacc-pat"]
+		if !ok {
+			return fmt.Errorf("OAuth2 token not found in state")
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("OAuth2 token ID not set")
+		}
+		if replaced && rs.Primary.ID == *id {
+			return fmt.Errorf("OAuth2 token %s wasn't replaced on rotation", *id)
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+func testAccOAuth2TokenConfig(month string) string {
+	return fmt.Sprintf(`
+resource "awx_oauth2_token" "testacc-pat" {
+	description = "CI personal access token"
+	rotation = {
+		month = "%s"
+	}
+}
+`, month)
+}
@@ -0,0 +1,348 @@
+package awx
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// resourceObjectAccessObject manages every direct role grant on one AWX
+// object. It is authoritative, grants of users and teams not declared in a
+// role block are revoked, including grants of roles without a block.
+func resourceObjectAccessObject() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceObjectAccessCreate,
+		Read:   resourceObjectAccessRead,
+		Delete: resourceObjectAccessDelete,
+		Update: resourceObjectAccessUpdate,
+
+		Schema: map[string]*schema.Schema{
+			"resource_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(roleResourceTypes(), false),
+			},
+			"resource_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"role": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:             schema.TypeString,
+							Required:         true,
+							DiffSuppressFunc: suppressEquivalentRoleName,
+						},
+						"user_ids": &schema.Schema{
+							Type:     schema.TypeSet,
+							Elem:     &schema.Schema{Type: schema.TypeInt},
+							Optional: true,
+						},
+						"team_ids": &schema.Schema{
+							Type:     schema.TypeSet,
+							Elem:     &schema.Schema{Type: schema.TypeInt},
+							Optional: true,
+						},
+					},
+				},
+				Description: "Users and teams holding each role, roles without a block are held by nobody",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: resourceObjectAccessImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+// roleMembers are the users and teams directly granted one role
+type roleMembers struct {
+	users map[int]bool
+	teams map[int]bool
+}
+
+func newRoleMembers() *roleMembers {
+	return &roleMembers{users: make(map[int]bool), teams: make(map[int]bool)}
+}
+
+// accessListEntry is the part of an access_list user needed to find direct grants
+type accessListEntry struct {
+	ID            int `json:"id"`
+	SummaryFields struct {
+		DirectAccess []struct {
+			Role struct {
+				ID int `json:"id"`
+			} `json:"role"`
+		} `json:"direct_access"`
+	} `json:"summary_fields"`
+}
+
+func resourceObjectAccessCreate(d *schema.ResourceData, m interface{}) error {
+	d.SetId(fmt.Sprintf("%s:%d", d.Get("resource_type").(string), d.Get("resource_id").(int)))
+	if err := applyObjectAccess(d, m, objectAccessFromConfig(d)); err != nil {
+		return objectAccessApplyError(d, m, err)
+	}
+	return resourceObjectAccessRead(d, m)
+}
+
+func resourceObjectAccessUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := applyObjectAccess(d, m, objectAccessFromConfig(d)); err != nil {
+		return objectAccessApplyError(d, m, err)
+	}
+	return resourceObjectAccessRead(d, m)
+}
+
+// objectAccessApplyError keeps the resource in the state after a failed
+// grant or revoke and refreshes it, so the roles already changed are tracked
+func objectAccessApplyError(d *schema.ResourceData, m interface{}, err error) error {
+	if readErr := resourceObjectAccessRead(d, m); readErr != nil {
+		return fmt.Errorf("%s, refreshing the access afterwards failed too, the state may be stale: %s", err, readErr)
+	}
+	return err
+}
+
+func resourceObjectAccessDelete(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	roles, err := getObjectRolesByID(awx, d.Get("resource_type").(string), d.Get("resource_id").(int))
+	if err != nil {
+		if isAPINotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	for name, members := range objectAccessFromConfig(d) {
+		roleID, err := roles.roleID(name)
+		if err != nil {
+			return err
+		}
+		if err := revokeRoleMembers(awx, roleID, members); err != nil {
+			return err
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+func resourceObjectAccessRead(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	roles, err := getObjectRolesByID(awx, d.Get("resource_type").(string), d.Get("resource_id").(int))
+	if err != nil {
+		if isAPINotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	current, err := readObjectAccess(awx, roles)
+	if err != nil {
+		return err
+	}
+	d.Set("role", flattenObjectAccess(d, current))
+	return nil
+}
+
+func resourceObjectAccessImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid ID %q, expected <resource_type>:<resource_id>", d.Id())
+	}
+	id, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("Invalid ID %q, %q is not numeric", d.Id(), parts[1])
+	}
+	d.Set("resource_type", parts[0])
+	d.Set("resource_id", id)
+	return []*schema.ResourceData{d}, nil
+}
+
+// applyObjectAccess grants and revokes roles until the direct grants of the
+// object match desired
+func applyObjectAccess(d *schema.ResourceData, m interface{}, desired map[string]*roleMembers) error {
+	awx := m.(*AWXClient)
+	roles, err := getObjectRolesByID(awx, d.Get("resource_type").(string), d.Get("resource_id").(int))
+	if err != nil {
+		return err
+	}
+	for name := range desired {
+		if _, err := roles.roleID(name); err != nil {
+			return err
+		}
+	}
+	current, err := readObjectAccess(awx, roles)
+	if err != nil {
+		return err
+	}
+	for _, name := range roles.names() {
+		roleID := roles.roles[name]
+		have, want := current[name], desired[name]
+		if have == nil {
+			have = newRoleMembers()
+		}
+		if want == nil {
+			want = newRoleMembers()
+		}
+		if err := revokeRoleMembers(awx, roleID, &roleMembers{
+			users: missingFrom(have.users, want.users),
+			teams: missingFrom(have.teams, want.teams),
+		}); err != nil {
+			return err
+		}
+		for u := range missingFrom(want.users, have.users) {
+			if err := awx.apiAssociate(roleGrant{collection: "users"}.endpoint(u), roleID); err != nil {
+				return fmt.Errorf("Failed to grant role %s to user %d: %s", name, u, err)
+			}
+		}
+		for t := range missingFrom(want.teams, have.teams) {
+			if err := awx.apiAssociate(roleGrant{collection: "teams"}.endpoint(t), roleID); err != nil {
+				return fmt.Errorf("Failed to grant role %s to team %d: %s", name, t, err)
+			}
+		}
+	}
+	return nil
+}
+
+func revokeRoleMembers(awx *AWXClient, roleID int, members *roleMembers) error {
+	for u := range members.users {
+		if err := awx.apiDisassociate(roleGrant{collection: "users"}.endpoint(u), roleID); err != nil {
+			return fmt.Errorf("Failed to revoke role %d from user %d: %s", roleID, u, err)
+		}
+	}
+	for t := range members.teams {
+		if err := awx.apiDisassociate(roleGrant{collection: "teams"}.endpoint(t), roleID); err != nil {
+			return fmt.Errorf("Failed to revoke role %d from team %d: %s", roleID, t, err)
+		}
+	}
+	return nil
+}
+
+// missingFrom returns the ids of a which are not in b
+func missingFrom(a, b map[int]bool) map[int]bool {
+	result := make(map[int]bool)
+	for id := range a {
+		if !b[id] {
+			result[id] = true
+		}
+	}
+	return result
+}
+
+// readObjectAccess returns the users and teams directly granted each role of
+// the object. Users come from the access_list, which also shows access
+// inherited from other objects, so only grants of the object's own roles are kept.
+func readObjectAccess(awx *AWXClient, roles *objectRoles) (map[string]*roleMembers, error) {
+	result := make(map[string]*roleMembers)
+	member := func(name string) *roleMembers {
+		if result[name] == nil {
+			result[name] = newRoleMembers()
+		}
+		return result[name]
+	}
+	collection := roleObjectTypes[roles.resourceType].collection
+	endpoint := fmt.Sprintf("/api/v2/%s/%d/access_list/", collection, roles.resourceID)
+	err := awx.apiList(endpoint, nil, func(raw json.RawMessage) error {
+		entry := new(accessListEntry)
+		if err := json.Unmarshal(raw, entry); err != nil {
+			return err
+		}
+		for _, a := range entry.SummaryFields.DirectAccess {
+			if name, ok := roles.roleName(a.Role.ID); ok {
+				member(name).users[entry.ID] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range roles.names() {
+		teams, err := awx.apiListIDs(fmt.Sprintf("/api/v2/roles/%d/teams/", roles.roles[name]), nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range teams {
+			member(name).teams[t] = true
+		}
+	}
+	return result, nil
+}
+
+func objectAccessFromConfig(d *schema.ResourceData) map[string]*roleMembers {
+	result := make(map[string]*roleMembers)
+	for _, raw := range d.Get("role").([]interface{}) {
+		block := raw.(map[string]interface{})
+		name := normalizeRoleName(block["name"].(string))
+		if result[name] == nil {
+			result[name] = newRoleMembers()
+		}
+		for _, u := range block["user_ids"].(*schema.Set).List() {
+			result[name].users[u.(int)] = true
+		}
+		for _, t := range block["team_ids"].(*schema.Set).List() {
+			result[name].teams[t.(int)] = true
+		}
+	}
+	return result
+}
+
+// flattenObjectAccess lists the roles in the order of the configuration, so
+// the plan shows grants and revocations inside the role blocks, followed by
+// the undeclared roles which are held by someone
+func flattenObjectAccess(d *schema.ResourceData, current map[string]*roleMembers) []interface{} {
+	var result []interface{}
+	seen := make(map[string]bool)
+	add := func(spelling, name string) {
+		members := current[name]
+		if members == nil {
+			members = newRoleMembers()
+		}
+		result = append(result, map[string]interface{}{
+			"name":     spelling,
+			"user_ids": sortedIDs(members.users),
+			"team_ids": sortedIDs(members.teams),
+		})
+		seen[name] = true
+	}
+	for _, raw := range d.Get("role").([]interface{}) {
+		spelling := raw.(map[string]interface{})["name"].(string)
+		if name := normalizeRoleName(spelling); !seen[name] {
+			add(spelling, name)
+		}
+	}
+	var others []string
+	for name, members := range current {
+		if !seen[name] && (len(members.users) > 0 || len(members.teams) > 0) {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	for _, name := range others {
+		add(name, name)
+	}
+	return result
+}
+
+func sortedIDs(ids map[int]bool) []interface{} {
+	var result []int
+	for id := range ids {
+		result = append(result, id)
+	}
+	sort.Ints(result)
+	return intsToInterfaces(result)
+}
@@ -0,0 +1,90 @@
+package awx
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestFlattenObjectAccess(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceObjectAccessObject().Schema, map[string]interface{}{
+		"resource_type": "inventory",
+		"resource_id":   3,
+		"role": []interface{}{
+			map[string]interface{}{"name": "use_role", "user_ids": []interface{}{5}},
+			map[string]interface{}{"name": "admin"},
+		},
+	})
+	current := map[string]*roleMembers{
+		"admin": &roleMembers{users: map[int]bool{7: true, 2: true}, teams: map[int]bool{}},
+		"read":  &roleMembers{users: map[int]bool{}, teams: map[int]bool{4: true}},
+		"adhoc": newRoleMembers(),
+	}
+	got := flattenObjectAccess(d, current)
+	want := []interface{}{
+		map[string]interface{}{"name": "use_role", "user_ids": []interface{}{}, "team_ids": []interface{}{}},
+		map[string]interface{}{"name": "admin", "user_ids": []interface{}{2, 7}, "team_ids": []interface{}{}},
+		map[string]interface{}{"name": "read", "user_ids": []interface{}{}, "team_ids": []interface{}{4}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenObjectAccess() = %v, want %v", got, want)
+	}
+}
+
+// awx_object_access test case
+func TestAccAWXObjectAccess(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { TestAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObjectAccessConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_object_access.testacc-access_1", "role.#", "2"),
+					resource.TestCheckResourceAttr("awx_object_access.testacc-access_1", "role.0.user_ids.#", "1"),
+					resource.TestCheckResourceAttr("awx_object_access.testacc-access_1", "role.1.team_ids.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "awx_object_access.testacc-access_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccObjectAccessConfig = `
+resource "awx_inventory" "testacc-access_1" {
+	name = "testacc-access_1"
+	organization_id = "1"
+}
+
+resource "awx_user" "testacc-access_1" {
+	username = "testacc-access_1"
+	password = "password"
+	email = "testacc-access_1@test.td"
+}
+
+resource "awx_team" "testacc-access_1" {
+	name = "testacc-access_1"
+	organization_id = "1"
+}
+
+resource "awx_object_access" "testacc-access_1" {
+	resource_type = "inventory"
+	resource_id = "${awx_inventory.testacc-access_1.id}"
+
+	role {
+		name = "admin"
+		user_ids = ["${awx_user.testacc-access_1.id}"]
+	}
+
+	role {
+		name = "use"
+		team_ids = ["${awx_team.testacc-access_1.id}"]
+	}
+}
+`
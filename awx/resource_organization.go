@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
-	awxgo "gitlab.com/dhendel/awx-go"
 )
 
 func resourceOrganizationObject() *schema.Resource {
@@ -35,6 +34,61 @@ func resourceOrganizationObject() *schema.Resource {
 				Default:     "",
 				Description: "The path of the custom virtualenv.",
 			},
+
+			"max_hosts": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Maximum number of hosts allowed to be managed by this organization, 0 means unlimited.",
+			},
+
+			"default_environment_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The default execution environment for jobs run by this organization.",
+			},
+
+			"galaxy_credential_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Optional:    true,
+				Description: "Ordered list of the Galaxy/Automation Hub credentials used to install collections.",
+			},
+
+			"instance_group_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Optional:    true,
+				Description: "Ordered list of the instance groups jobs of this organization run on.",
+			},
+
+			"notification_template_started_ids": &schema.Schema{
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Optional:    true,
+				Description: "Notification templates sent when a job starts.",
+			},
+
+			"notification_template_success_ids": &schema.Schema{
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Optional:    true,
+				Description: "Notification templates sent when a job succeeds.",
+			},
+
+			"notification_template_error_ids": &schema.Schema{
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Optional:    true,
+				Description: "Notification templates sent when a job fails.",
+			},
+
+			"notification_template_approvals_ids": &schema.Schema{
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Optional:    true,
+				Description: "Notification templates sent when a workflow approval is pending or decided.",
+			},
 		},
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
@@ -49,54 +103,43 @@ func resourceOrganizationObject() *schema.Resource {
 }
 
 func resourceOrganizationCreate(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
-	awxService := awx.OrganizationService
-	_, res, err := awxService.ListOrganizations(map[string]string{
+	awx := m.(*AWXClient)
+	existing, err := awx.apiListIDs("/api/v2/organizations/", map[string]string{
 		"name": d.Get("name").(string)})
 	if err != nil {
 		return err
 	}
-	if len(res.Results) >= 1 {
+	if len(existing) >= 1 {
 		return fmt.Errorf("Organization with name %s already exists",
 			d.Get("name").(string))
 	}
 
-	result, err := awxService.CreateOrganization(map[string]interface{}{
-		"name":              d.Get("name").(string),
-		"description":       d.Get("description").(string),
-		"custom_virtualenv": d.Get("custom_virtualenv").(string),
-	}, map[string]string{})
-	if err != nil {
+	result := new(organization)
+	if err := awx.apiPost("/api/v2/organizations/", organizationPayload(d), result); err != nil {
 		return err
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
+	if err := setOrganizationLinks(awx, d, result.ID, true); err != nil {
+		return err
+	}
 	return resourceOrganizationRead(d, m)
 }
 
 func resourceOrganizationUpdate(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
-	awxService := awx.OrganizationService
-	_, res, err := awxService.ListOrganizations(map[string]string{
-		"id": d.Id()},
-	)
+	awx := m.(*AWXClient)
+	id, err := strconv.Atoi(d.Id())
 	if err != nil {
 		return err
 	}
-	if len(res.Results) == 0 {
-		return fmt.Errorf("Organization with name %s doesn't exists",
-			d.Get("name").(string))
-	}
-	id, err := strconv.Atoi(d.Id())
-	if err != nil {
+	if err := awx.apiPatch(fmt.Sprintf("/api/v2/organizations/%d/", id), organizationPayload(d), nil); err != nil {
+		if isAPINotFound(err) {
+			return fmt.Errorf("Organization with name %s doesn't exists",
+				d.Get("name").(string))
+		}
 		return err
 	}
-	_, err = awxService.UpdateOrganization(id, map[string]interface{}{
-		"name":              d.Get("name").(string),
-		"description":       d.Get("description").(string),
-		"custom_virtualenv": d.Get("custom_virtualenv").(string),
-	}, map[string]string{})
-	if err != nil {
+	if err := setOrganizationLinks(awx, d, id, false); err != nil {
 		return err
 	}
 
@@ -104,46 +147,120 @@ func resourceOrganizationUpdate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceOrganizationRead(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
-	awxService := awx.OrganizationService
-	_, res, err := awxService.ListOrganizations(map[string]string{
-		"name": d.Get("name").(string)})
+	awx := m.(*AWXClient)
+	id, err := strconv.Atoi(d.Id())
 	if err != nil {
+		return fmt.Errorf("Organization %s not found", d.Id())
+	}
+	r := new(organization)
+	if err := awx.apiGet(fmt.Sprintf("/api/v2/organizations/%d/", id), r, nil); err != nil {
+		if isAPINotFound(err) {
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
-	if len(res.Results) == 0 {
-		return nil
+	d = setOrganizationResourceData(d, r)
+	for attr, related := range organizationOrderedLinks {
+		ids, err := awx.apiListIDs(fmt.Sprintf("/api/v2/organizations/%d/%s/", id, related), nil)
+		if err != nil {
+			return err
+		}
+		d.Set(attr, ids)
+	}
+	for attr, related := range organizationLinks {
+		ids, err := awx.apiListIDs(fmt.Sprintf("/api/v2/organizations/%d/%s/", id, related), nil)
+		if err != nil {
+			return err
+		}
+		d.Set(attr, ids)
 	}
-	d = setOrganizationResourceData(d, res.Results[0])
 	return nil
 }
 
 func resourceOrganizationDelete(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
-	awxService := awx.OrganizationService
+	awx := m.(*AWXClient)
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
 		return err
 	}
-	_, res, err := awxService.ListOrganizations(map[string]string{
-		"name": d.Get("name").(string)})
-	if len(res.Results) == 0 {
-		d.SetId("")
-		return nil
-	}
-	if err != nil {
-		return err
-	}
-	if _, err = awxService.DeleteOrganization(id); err != nil {
+	if err := awx.apiDelete(fmt.Sprintf("/api/v2/organizations/%d/", id)); err != nil && !isAPINotFound(err) {
 		return err
 	}
 	d.SetId("")
 	return nil
 }
 
-func setOrganizationResourceData(d *schema.ResourceData, r *awxgo.Organization) *schema.ResourceData {
+// organization represents the awx api organization, awx-go only implements part of it
+type organization struct {
+	ID                 int         `json:"id"`
+	Name               string      `json:"name"`
+	Description        string      `json:"description"`
+	CustomVirtualEnv   interface{} `json:"custom_virtualenv"`
+	MaxHosts           int         `json:"max_hosts"`
+	DefaultEnvironment *int        `json:"default_environment"`
+}
+
+// organizationOrderedLinks maps the ordered list attributes to their related endpoints
+var organizationOrderedLinks = map[string]string{
+	"galaxy_credential_ids": "galaxy_credentials",
+	"instance_group_ids":    "instance_groups",
+}
+
+// organizationLinks maps the set attributes to their related endpoints
+var organizationLinks = map[string]string{
+	"notification_template_started_ids":   "notification_templates_started",
+	"notification_template_success_ids":   "notification_templates_success",
+	"notification_template_error_ids":     "notification_templates_error",
+	"notification_template_approvals_ids": "notification_templates_approvals",
+}
+
+func organizationPayload(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                d.Get("name").(string),
+		"description":         d.Get("description").(string),
+		"custom_virtualenv":   d.Get("custom_virtualenv").(string),
+		"max_hosts":           d.Get("max_hosts").(int),
+		"default_environment": optionalID(d, "default_environment_id"),
+	}
+}
+
+// setOrganizationLinks associates and disassociates the related credentials,
+// instance groups and notification templates, only changed lists are touched
+// unless the organization was just created
+func setOrganizationLinks(awx *AWXClient, d *schema.ResourceData, id int, created bool) error {
+	for attr, related := range organizationOrderedLinks {
+		if !created && !d.HasChange(attr) {
+			continue
+		}
+		o, n := d.GetChange(attr)
+		endpoint := fmt.Sprintf("/api/v2/organizations/%d/%s/", id, related)
+		if err := awx.apiReplaceOrderedAssociations(endpoint, o.([]interface{}), n.([]interface{})); err != nil {
+			return err
+		}
+	}
+	for attr, related := range organizationLinks {
+		if !created && !d.HasChange(attr) {
+			continue
+		}
+		o, n := d.GetChange(attr)
+		endpoint := fmt.Sprintf("/api/v2/organizations/%d/%s/", id, related)
+		if err := awx.apiUpdateAssociations(endpoint, o.(*schema.Set), n.(*schema.Set)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setOrganizationResourceData(d *schema.ResourceData, r *organization) *schema.ResourceData {
 	d.Set("name", r.Name)
 	d.Set("description", r.Description)
-	d.Set("custom_virtualenv", r.CustomVirtualEnv)
+	if venv, ok := r.CustomVirtualEnv.(string); ok {
+		d.Set("custom_virtualenv", venv)
+	} else {
+		d.Set("custom_virtualenv", "")
+	}
+	d.Set("max_hosts", r.MaxHosts)
+	d.Set("default_environment_id", intOrZero(r.DefaultEnvironment))
 	return d
 }
@@ -0,0 +1,15 @@
+package awx
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceOrganizationMemberObject makes a user member of an organization
+func resourceOrganizationMemberObject() *schema.Resource {
+	return resourceUserLinkObject("organization_id", "organizations", "users")
+}
+
+// resourceOrganizationAdminObject makes a user administrator of an organization
+func resourceOrganizationAdminObject() *schema.Resource {
+	return resourceUserLinkObject("organization_id", "organizations", "admins")
+}
@@ -0,0 +1,58 @@
+package awx
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+// awx_organization_member and awx_organization_admin test case
+func TestAccAWXOrganizationMember(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { TestAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrganizationMemberConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("awx_organization_member.testacc-member_1", "user_id",
+						"awx_user.testacc-member_1", "id"),
+					resource.TestCheckResourceAttrPair("awx_organization_admin.testacc-admin_1", "organization_id",
+						"awx_organization.testacc-member_1", "id"),
+				),
+			},
+			{
+				ResourceName:      "awx_organization_member.testacc-member_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_organization_admin.testacc-admin_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccOrganizationMemberConfig = `
+resource "awx_organization" "testacc-member_1" {
+	name = "testacc-member_1"
+}
+
+resource "awx_user" "testacc-member_1" {
+	username = "testacc-member_1"
+	password = "password"
+	email = "testacc-member_1@test.td"
+}
+
+resource "awx_organization_member" "testacc-member_1" {
+	organization_id = "${awx_organization.testacc-member_1.id}"
+	user_id = "${awx_user.testacc-member_1.id}"
+}
+
+resource "awx_organization_admin" "testacc-admin_1" {
+	organization_id = "${awx_organization.testacc-member_1.id}"
+	user_id = "${awx_user.testacc-member_1.id}"
+}
+`
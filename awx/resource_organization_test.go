@@ -2,12 +2,59 @@ package awx
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
 
+// TestOrganizationCreateDelete runs create and delete against a fake AWX, they
+// only go through the raw API helpers
+func TestOrganizationCreateDelete(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v2/organizations/5/":
+			w.Write([]byte(`{"id": 5, "name": "testacc-org", "max_hosts": 10}`))
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/api/v2/organizations/"):
+			w.Write([]byte(`{"count": 0, "next": null, "results": []}`))
+		case r.Method == "POST" && r.URL.Path == "/api/v2/organizations/":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": 5, "name": "testacc-org"}`))
+		case r.Method == "DELETE" && r.URL.Path == "/api/v2/organizations/5/":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	awx := (&Config{Endpoint: server.URL}).Client()
+	d := schema.TestResourceDataRaw(t, resourceOrganizationObject().Schema, map[string]interface{}{
+		"name":      "testacc-org",
+		"max_hosts": 10,
+	})
+	if err := resourceOrganizationCreate(d, awx); err != nil {
+		t.Fatalf("resourceOrganizationCreate() = %s", err)
+	}
+	if d.Id() != "5" || d.Get("max_hosts").(int) != 10 {
+		t.Errorf("ID = %q, max_hosts = %d after create, want 5 and 10", d.Id(), d.Get("max_hosts").(int))
+	}
+	if err := resourceOrganizationDelete(d, awx); err != nil {
+		t.Fatalf("resourceOrganizationDelete() = %s", err)
+	}
+	if d.Id() != "" || requests[len(requests)-1] != "DELETE /api/v2/organizations/5/" {
+		t.Errorf("ID = %q after delete, last request %s", d.Id(), requests[len(requests)-1])
+	}
+}
+
 // awx_organization test case
 func TestAccAWXOrganization(t *testing.T) {
 	resource.Test(t, resource.TestCase{
@@ -19,8 +66,14 @@ func TestAccAWXOrganization(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckStateOrganization("name", "automation_organization"),
 					testAccCheckStateOrganization("description", "Automation Organization"),
+					testAccCheckStateOrganization("max_hosts", "10"),
 				),
 			},
+			{
+				ResourceName:      "awx_organization.testacc-organization_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -49,5 +102,6 @@ const testAccOrganizationConfig = `
 resource "awx_organization" "testacc-organization_1" {
 	name = "automation_organization"
 	description = "Automation Organization"
+	max_hosts = 10
   }
 `
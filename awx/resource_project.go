@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	awxgo "gitlab.com/dhendel/awx-go"
 )
@@ -85,21 +86,47 @@ func resourceProjectObject() *schema.Resource {
 				Optional: true,
 				Default:  0,
 			},
+			"execution_environment_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Default execution environment of the job templates using this project",
+			},
+			"triggers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that launch a project update and wait for it when changed",
+			},
+			"scm_revision": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SCM revision of the last successful project update",
+			},
+			"last_update_status": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the last project update",
+			},
+			"last_updated": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Finish time of the last project update, RFC3339 formatted",
+			},
 		},
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(1 * time.Minute),
-			Update: schema.DefaultTimeout(1 * time.Minute),
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
 			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 	}
 }
 
 func resourceProjectCreate(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.ProjectService
 
 	_, res, err := awxService.ListProjects(map[string]string{
@@ -110,8 +137,8 @@ func resourceProjectCreate(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 	if len(res.Results) >= 1 {
-		return fmt.Errorf("Project with name %s already exists in the organization %s",
-			d.Get("name").(string), d.Get("organization_id").(string))
+		return fmt.Errorf("Project with name %s already exists in the organization %d",
+			d.Get("name").(string), d.Get("organization_id").(int))
 	}
 
 	result, err := awxService.CreateProject(map[string]interface{}{
@@ -127,17 +154,24 @@ func resourceProjectCreate(d *schema.ResourceData, m interface{}) error {
 		"organization":             d.Get("organization_id").(int),
 		"scm_update_on_launch":     d.Get("scm_update_on_launch").(bool),
 		"scm_update_cache_timeout": d.Get("scm_update_cache_timeout").(int),
+		"default_environment":      optionalID(d, "execution_environment_id"),
 	}, map[string]string{})
 	if err != nil {
 		return err
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
+
+	if _, ok := d.GetOk("triggers"); ok {
+		if err := syncProject(awx, result.ID, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
 	return resourceProjectRead(d, m)
 }
 
 func resourceProjectUpdate(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.ProjectService
 	_, res, err := awxService.ListProjects(map[string]string{
 		"id":           d.Id(),
@@ -147,8 +181,8 @@ func resourceProjectUpdate(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 	if len(res.Results) == 0 {
-		return fmt.Errorf("Project with name %s doesn't exists in the organization %s",
-			d.Get("name").(string), d.Get("organization_id").(string))
+		return fmt.Errorf("Project with name %s doesn't exists in the organization %d",
+			d.Get("name").(string), d.Get("organization_id").(int))
 	}
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
@@ -164,19 +198,25 @@ func resourceProjectUpdate(d *schema.ResourceData, m interface{}) error {
 		"scm_clean":                d.Get("scm_clean").(bool),
 		"scm_delete_on_update":     d.Get("scm_delete_on_update").(bool),
 		"credential_id":            AtoipOr(d.Get("credential_id").(string), nil),
-		"organization":             AtoipOr(d.Get("organization_id").(string), nil),
+		"organization":             d.Get("organization_id").(int),
 		"scm_update_on_launch":     d.Get("scm_update_on_launch").(bool),
 		"scm_update_cache_timeout": d.Get("scm_update_cache_timeout").(int),
+		"default_environment":      optionalID(d, "execution_environment_id"),
 	}, map[string]string{})
 	if err != nil {
 		return err
 	}
 
+	if d.HasChange("triggers") {
+		if err := syncProject(awx, id, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
 	return resourceProjectRead(d, m)
 }
 
 func resourceProjectRead(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.ProjectService
 	_, res, err := awxService.ListProjects(map[string]string{
 		"name": d.Get("name").(string)})
@@ -187,11 +227,16 @@ func resourceProjectRead(d *schema.ResourceData, m interface{}) error {
 		return nil
 	}
 	d = setProjectResourceData(d, res.Results[0])
+	env, err := getExecutionEnvironmentFields(awx, fmt.Sprintf("/api/v2/projects/%d/", res.Results[0].ID))
+	if err != nil {
+		return err
+	}
+	d.Set("execution_environment_id", intOrZero(env.DefaultEnvironment))
 	return nil
 }
 
 func resourceProjectDelete(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.ProjectService
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
@@ -247,5 +292,56 @@ func setProjectResourceData(d *schema.ResourceData, r *awxgo.Project) *schema.Re
 	d.Set("organization_id", r.Organization)
 	d.Set("scm_update_on_launch", r.ScmUpdateOnLaunch)
 	d.Set("scm_update_cache_timeout", r.ScmUpdateCacheTimeout)
+	d.Set("scm_revision", r.ScmRevision)
+	d.Set("last_update_status", projectLastUpdateStatus(r))
+	d.Set("last_updated", projectLastUpdated(r))
 	return d
 }
+
+// projectUpdateLaunch is returned by the project update endpoint
+type projectUpdateLaunch struct {
+	ProjectUpdate int `json:"project_update"`
+}
+
+// syncProject launches a project update and waits for it to finish
+func syncProject(awx *AWXClient, id int, timeout time.Duration) error {
+	launch := new(projectUpdateLaunch)
+	err := awx.apiPost(fmt.Sprintf("/api/v2/projects/%d/update/", id), map[string]interface{}{}, launch)
+	if err != nil {
+		return fmt.Errorf("Failed to launch update of project %d: %s", id, err)
+	}
+	return waitForProjectUpdate(awx, launch.ProjectUpdate, timeout)
+}
+
+func waitForProjectUpdate(awx *AWXClient, jobID int, timeout time.Duration) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		job, err := awx.ProjectUpdatesService.ProjectUpdateGet(jobID)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		switch job.Status {
+		case "successful":
+			return nil
+		case "failed", "error", "canceled":
+			return resource.NonRetryableError(fmt.Errorf("Project update %d finished with status %s: %s",
+				jobID, job.Status, job.JobExplanation))
+		}
+		return resource.RetryableError(fmt.Errorf("Project update %d is %s", jobID, job.Status))
+	})
+}
+
+func projectLastUpdateStatus(r *awxgo.Project) string {
+	if r.SummaryFields != nil && r.SummaryFields.LastUpdate != nil {
+		if status, ok := r.SummaryFields.LastUpdate["status"].(string); ok {
+			return status
+		}
+	}
+	return r.Status
+}
+
+func projectLastUpdated(r *awxgo.Project) string {
+	if r.LastUpdated.IsZero() {
+		return ""
+	}
+	return r.LastUpdated.Format(time.RFC3339)
+}
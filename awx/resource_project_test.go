@@ -23,6 +23,7 @@ func TestAccAWXProject(t *testing.T) {
 					testAccCheckStateProject("scm_type", "git"),
 					testAccCheckStateProject("scm_update_on_launch", "true"),
 					testAccCheckStateProject("scm_url", "https://github.com/ansible/ansible-tower-samples"),
+					testAccCheckStateProject("last_update_status", "successful"),
 				),
 			},
 		},
@@ -57,5 +58,8 @@ resource "awx_project" "testacc-prj_1" {
 	scm_url = "https://github.com/ansible/ansible-tower-samples"
 	scm_update_on_launch = true
 	organization_id = "1"
+	triggers = {
+		branch_head = "master"
+	}
   }
 `
@@ -0,0 +1,219 @@
+package awx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// roleGrant describes the principal, a user or a team, roles are granted to
+type roleGrant struct {
+	principalAttr string
+	collection    string
+}
+
+func (g roleGrant) endpoint(principalID int) string {
+	return fmt.Sprintf("/api/v2/%s/%d/roles/", g.collection, principalID)
+}
+
+// role is the part of the awx api role used to describe a grant
+type role struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	SummaryFields struct {
+		ResourceName string `json:"resource_name"`
+		ResourceType string `json:"resource_type"`
+		ResourceID   int    `json:"resource_id"`
+	} `json:"summary_fields"`
+}
+
+// resourceRoleGrantObject grants a role on an AWX object to a user or team.
+// The ID is <principal id>:<role id>, so a principal can hold several grants.
+func resourceRoleGrantObject(principalAttr, collection string) *schema.Resource {
+	g := roleGrant{principalAttr: principalAttr, collection: collection}
+	return &schema.Resource{
+		Create: func(d *schema.ResourceData, m interface{}) error {
+			return resourceRoleGrantCreate(d, m, g)
+		},
+		Read: func(d *schema.ResourceData, m interface{}) error {
+			return resourceRoleGrantRead(d, m, g)
+		},
+		Delete: func(d *schema.ResourceData, m interface{}) error {
+			return resourceRoleGrantDelete(d, m, g)
+		},
+
+		Schema: map[string]*schema.Schema{
+			principalAttr: &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"organization_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Organization scoping the resource_name lookup",
+			},
+			"role": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressEquivalentRoleName,
+				Description:      "Name of the role, e.g. admin, use or job template admin, valid roles are read from the object",
+			},
+			"resource_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(roleResourceTypes(), false),
+			},
+			"resource_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"resource_id": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Id of the object the role belongs to, looks it up instead of resource_name",
+			},
+			"role_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				return resourceRoleGrantImport(d, m, g)
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(1 * time.Minute),
+		},
+	}
+}
+
+func resourceRoleGrantCreate(d *schema.ResourceData, m interface{}, g roleGrant) error {
+	awx := m.(*AWXClient)
+	principalID, err := strconv.Atoi(d.Get(g.principalAttr).(string))
+	if err != nil {
+		return fmt.Errorf("Invalid %s %q", g.principalAttr, d.Get(g.principalAttr).(string))
+	}
+	if d.Get("resource_id").(int) == 0 && d.Get("resource_name").(string) == "" {
+		return fmt.Errorf("One of resource_id or resource_name must be set")
+	}
+	roleID, err := getRoleID(d, m)
+	if err != nil {
+		return err
+	}
+	if err := awx.apiAssociate(g.endpoint(principalID), roleID); err != nil {
+		return fmt.Errorf("Failed to grant role %d to %s %d: %s", roleID, strings.TrimSuffix(g.collection, "s"), principalID, err)
+	}
+	d.SetId(compositeID(principalID, roleID))
+	return resourceRoleGrantRead(d, m, g)
+}
+
+func resourceRoleGrantRead(d *schema.ResourceData, m interface{}, g roleGrant) error {
+	awx := m.(*AWXClient)
+	// IDs used to be the bare principal ID, upgrade them to principal:role_id
+	if !strings.Contains(d.Id(), ":") {
+		roleID, err := getRoleID(d, m)
+		if err != nil {
+			return err
+		}
+		d.SetId(d.Id() + ":" + strconv.Itoa(roleID))
+	}
+	principalID, roleID, err := parseCompositeID(d.Id())
+	if err != nil {
+		return err
+	}
+	held, err := awx.apiIsAssociated(g.endpoint(principalID), roleID)
+	if err != nil {
+		if isAPINotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	// A role revoked outside of Terraform is planned to be granted again
+	if !held {
+		d.SetId("")
+		return nil
+	}
+	r := new(role)
+	if err := awx.apiGet(fmt.Sprintf("/api/v2/roles/%d/", roleID), r, nil); err != nil {
+		return err
+	}
+	roles, err := getObjectRoles(awx, r.SummaryFields.ResourceType, map[string]string{
+		"id": strconv.Itoa(r.SummaryFields.ResourceID),
+	})
+	if err != nil {
+		return err
+	}
+	d = setRoleGrantResourceData(d, g, principalID, r, roles)
+	return nil
+}
+
+// resourceRoleGrantImport checks the role of an imported grant belongs to an
+// object type the resource supports, Read can't resolve the others
+func resourceRoleGrantImport(d *schema.ResourceData, m interface{}, g roleGrant) ([]*schema.ResourceData, error) {
+	awx := m.(*AWXClient)
+	_, roleID, err := parseCompositeID(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("%s, grants are imported as <%s>:<role id>", err, g.principalAttr)
+	}
+	r := new(role)
+	if err := awx.apiGet(fmt.Sprintf("/api/v2/roles/%d/", roleID), r, nil); err != nil {
+		return nil, err
+	}
+	if _, ok := roleObjectTypes[r.SummaryFields.ResourceType]; !ok {
+		resourceType := r.SummaryFields.ResourceType
+		if resourceType == "" {
+			resourceType = "system"
+		}
+		return nil, fmt.Errorf("Role %d is a %s role, only roles on %s can be imported",
+			roleID, resourceType, strings.Join(roleResourceTypes(), ", "))
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceRoleGrantDelete(d *schema.ResourceData, m interface{}, g roleGrant) error {
+	awx := m.(*AWXClient)
+	principalID, roleID, err := parseCompositeID(d.Id())
+	if err != nil {
+		return err
+	}
+	if err := awx.apiDisassociate(g.endpoint(principalID), roleID); err != nil {
+		return fmt.Errorf("Failed to revoke role %d from %s %d: %s", roleID, strings.TrimSuffix(g.collection, "s"), principalID, err)
+	}
+	d.SetId("")
+	return nil
+}
+
+func suppressEquivalentRoleName(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeRoleName(old) == normalizeRoleName(new)
+}
+
+func setRoleGrantResourceData(d *schema.ResourceData, g roleGrant, principalID int, r *role, roles *objectRoles) *schema.ResourceData {
+	d.Set(g.principalAttr, strconv.Itoa(principalID))
+	d.Set("role_id", r.ID)
+	if name, ok := roles.roleName(r.ID); ok && normalizeRoleName(d.Get("role").(string)) != name {
+		d.Set("role", name)
+	}
+	d.Set("resource_type", r.SummaryFields.ResourceType)
+	d.Set("resource_id", r.SummaryFields.ResourceID)
+	// Objects may be renamed, the name is only filled in on import
+	if d.Get("resource_name").(string) == "" {
+		d.Set("resource_name", r.SummaryFields.ResourceName)
+	}
+	return d
+}
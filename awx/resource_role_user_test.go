@@ -2,12 +2,47 @@ package awx
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
 
+// TestUserRoleImportObjectType imports a grant on an inventory and one on a
+// system role, which has no object and is rejected
+func TestUserRoleImportObjectType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v2/roles/7/":
+			w.Write([]byte(`{"id": 7, "name": "Admin", "summary_fields": {"resource_type": "inventory", "resource_id": 3}}`))
+		case "/api/v2/roles/1/":
+			w.Write([]byte(`{"id": 1, "name": "System Auditor", "summary_fields": {}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	awx := (&Config{Endpoint: server.URL}).Client()
+	importer := resourceUserRoleObject().Importer.State
+	for id, want := range map[string]string{"4:7": "", "4:1": "is a system role", "4": "grants are imported as <user_id>:<role id>"} {
+		d := schema.TestResourceDataRaw(t, resourceUserRoleObject().Schema, map[string]interface{}{})
+		d.SetId(id)
+		_, err := importer(d, awx)
+		if want == "" && err != nil {
+			t.Errorf("import %s: %s", id, err)
+		}
+		if want != "" && (err == nil || !strings.Contains(err.Error(), want)) {
+			t.Errorf("import %s: error %v, want it to contain %q", id, err, want)
+		}
+	}
+}
+
 // awx_user test case
 func TestAccAWXUserRole(t *testing.T) {
 	resource.Test(t, resource.TestCase{
@@ -20,8 +55,16 @@ func TestAccAWXUserRole(t *testing.T) {
 					testAccCheckStateUserRole("role", "admin"),
 					testAccCheckStateUserRole("resource_type", "inventory"),
 					testAccCheckStateUserRole("resource_name", "Demo Inventory"),
+					resource.TestCheckResourceAttrPair("awx_user_role.testacc-user_role_3", "resource_id",
+						"awx_user_role.testacc-user_role_1", "resource_id"),
 				),
 			},
+			{
+				ResourceName:            "awx_user_role.testacc-user_role_1",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"organization_id"},
+			},
 		},
 	})
 }
@@ -62,4 +105,11 @@ resource "awx_user_role" "testacc-user_role_1" {
 	resource_name = "organization"
 	role = "inventory admin"
   }
+
+  resource "awx_user_role" "testacc-user_role_3" {
+	user_id = 1
+	resource_type = "inventory"
+	resource_id = "${awx_user_role.testacc-user_role_1.resource_id}"
+	role = "use"
+  }
 `
@@ -0,0 +1,187 @@
+package awx
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceSettingsObject manages settings of one category. Only the declared
+// keys are managed, removed keys are restored to their default.
+func resourceSettingsObject() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSettingsCreate,
+		Read:   resourceSettingsRead,
+		Delete: resourceSettingsDelete,
+		Update: resourceSettingsUpdate,
+
+		Schema: map[string]*schema.Schema{
+			"category": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Settings category, e.g. system, jobs, ui, logging or authentication",
+			},
+			"settings": &schema.Schema{
+				Type:             schema.TypeMap,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				Optional:         true,
+				ValidateFunc:     validateSettingsValues,
+				DiffSuppressFunc: suppressEquivalentSettingValue,
+				Description:      "Setting names mapped to their JSON encoded value",
+			},
+			"sensitive_settings": &schema.Schema{
+				Type:             schema.TypeMap,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				Optional:         true,
+				Sensitive:        true,
+				ValidateFunc:     validateSettingsValues,
+				DiffSuppressFunc: suppressEquivalentSettingValue,
+				Description:      "Secret settings like passwords, mapped to their JSON encoded value. AWX never returns them, so changes made outside of Terraform aren't detected",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(1 * time.Minute),
+		},
+	}
+}
+
+func validateSettingsValues(v interface{}, k string) (ws []string, errors []error) {
+	for key, value := range v.(map[string]interface{}) {
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(value.(string)), &parsed); err != nil {
+			errors = append(errors, fmt.Errorf("%s.%s must be JSON encoded, use jsonencode(): %s", k, key, err))
+		}
+	}
+	return
+}
+
+func suppressEquivalentSettingValue(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" || new == "" || strings.HasSuffix(k, ".%") {
+		return false
+	}
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(new), &parsed); err != nil {
+		return false
+	}
+	return settingJSONEqual(old, parsed)
+}
+
+// declaredSettings decodes the settings and sensitive_settings maps
+func declaredSettings(settings, sensitive interface{}) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	for _, m := range []interface{}{settings, sensitive} {
+		for k, v := range m.(map[string]interface{}) {
+			if _, ok := result[k]; ok {
+				return nil, fmt.Errorf("Setting %s is declared in both settings and sensitive_settings", k)
+			}
+			var parsed interface{}
+			if err := json.Unmarshal([]byte(v.(string)), &parsed); err != nil {
+				return nil, fmt.Errorf("Setting %s is not valid JSON: %s", k, err)
+			}
+			result[k] = parsed
+		}
+	}
+	return result, nil
+}
+
+// declaredSettingKeys returns the keys of the settings and sensitive_settings maps
+func declaredSettingKeys(settings, sensitive interface{}) []string {
+	keys := make(map[string]interface{})
+	for _, m := range []interface{}{settings, sensitive} {
+		for k, v := range m.(map[string]interface{}) {
+			keys[k] = v
+		}
+	}
+	return settingsKeys(keys)
+}
+
+func resourceSettingsCreate(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	category := d.Get("category").(string)
+	declared, err := declaredSettings(d.Get("settings"), d.Get("sensitive_settings"))
+	if err != nil {
+		return err
+	}
+	if err := updateSettings(awx, category, declared, nil); err != nil {
+		return err
+	}
+	d.SetId(category)
+	return resourceSettingsRead(d, m)
+}
+
+func resourceSettingsUpdate(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	oldSettings, newSettings := d.GetChange("settings")
+	oldSensitive, newSensitive := d.GetChange("sensitive_settings")
+	declared, err := declaredSettings(newSettings, newSensitive)
+	if err != nil {
+		return err
+	}
+	var removed []string
+	for _, k := range declaredSettingKeys(oldSettings, oldSensitive) {
+		if _, ok := declared[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+	if err := updateSettings(awx, d.Id(), declared, removed); err != nil {
+		return err
+	}
+	return resourceSettingsRead(d, m)
+}
+
+func resourceSettingsRead(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	current, err := getSettings(awx, d.Id())
+	if err != nil {
+		if isAPINotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	d.Set("category", d.Id())
+	d.Set("settings", readSettingsValues(d.Get("settings").(map[string]interface{}), current))
+	d.Set("sensitive_settings", readSettingsValues(d.Get("sensitive_settings").(map[string]interface{}), current))
+	return nil
+}
+
+func resourceSettingsDelete(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	keys := declaredSettingKeys(d.Get("settings"), d.Get("sensitive_settings"))
+	if err := updateSettings(awx, d.Id(), nil, keys); err != nil && !isAPINotFound(err) {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+// readSettingsValues refreshes the managed keys from the current settings. The
+// configured encoding is kept for equivalent values, encrypted values can't be
+// compared and are kept as they are.
+func readSettingsValues(managed, current map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	for k, v := range managed {
+		value, ok := current[k]
+		if !ok {
+			continue
+		}
+		if value == encryptedSetting || settingJSONEqual(v.(string), value) {
+			result[k] = v
+			continue
+		}
+		if b, err := json.Marshal(value); err == nil {
+			result[k] = string(b)
+		}
+	}
+	return result
+}
@@ -0,0 +1,121 @@
+package awx
+
+import (
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Typed resources of the SAML, OIDC and social authentication categories.
+// Organization and team maps are maps of JSON encoded values keyed by
+// organization or team name, so the plan shows changes of single entries.
+
+var samlSettingFields = []settingField{
+	{attr: "callback_url", key: "SOCIAL_AUTH_SAML_CALLBACK_URL", kind: settingString, readOnly: true},
+	{attr: "metadata_url", key: "SOCIAL_AUTH_SAML_METADATA_URL", kind: settingString, readOnly: true},
+	{attr: "sp_entity_id", key: "SOCIAL_AUTH_SAML_SP_ENTITY_ID", kind: settingString},
+	{attr: "sp_public_cert", key: "SOCIAL_AUTH_SAML_SP_PUBLIC_CERT", kind: settingString, validate: validatePEM},
+	{attr: "sp_private_key", key: "SOCIAL_AUTH_SAML_SP_PRIVATE_KEY", kind: settingString, sensitive: true, validate: validatePEM},
+	{attr: "org_info", key: "SOCIAL_AUTH_SAML_ORG_INFO", kind: settingJSONMap,
+		description: "JSON encoded name, displayname and url of the organization, keyed by language code"},
+	{attr: "technical_contact", key: "SOCIAL_AUTH_SAML_TECHNICAL_CONTACT", kind: settingStringMap},
+	{attr: "support_contact", key: "SOCIAL_AUTH_SAML_SUPPORT_CONTACT", kind: settingStringMap},
+	{attr: "enabled_idps", key: "SOCIAL_AUTH_SAML_ENABLED_IDPS", kind: settingJSONMap,
+		description: "JSON encoded entity_id, url, x509cert and attribute names of each identity provider"},
+	{attr: "security_config", key: "SOCIAL_AUTH_SAML_SECURITY_CONFIG", kind: settingJSON},
+	{attr: "sp_extra", key: "SOCIAL_AUTH_SAML_SP_EXTRA", kind: settingJSON},
+	{attr: "extra_data", key: "SOCIAL_AUTH_SAML_EXTRA_DATA", kind: settingJSON},
+	{attr: "organization_map", key: "SOCIAL_AUTH_SAML_ORGANIZATION_MAP", kind: settingJSONMap},
+	{attr: "team_map", key: "SOCIAL_AUTH_SAML_TEAM_MAP", kind: settingJSONMap},
+	{attr: "organization_attr", key: "SOCIAL_AUTH_SAML_ORGANIZATION_ATTR", kind: settingJSON,
+		description: "JSON encoded mapping of a SAML attribute to organization membership"},
+	{attr: "team_attr", key: "SOCIAL_AUTH_SAML_TEAM_ATTR", kind: settingJSON,
+		description: "JSON encoded mapping of a SAML attribute to team membership"},
+	{attr: "user_flags_by_attr", key: "SOCIAL_AUTH_SAML_USER_FLAGS_BY_ATTR", kind: settingJSON},
+}
+
+var oidcSettingFields = []settingField{
+	{attr: "key", key: "SOCIAL_AUTH_OIDC_KEY", kind: settingString},
+	{attr: "secret", key: "SOCIAL_AUTH_OIDC_SECRET", kind: settingString, sensitive: true},
+	{attr: "oidc_endpoint", key: "SOCIAL_AUTH_OIDC_OIDC_ENDPOINT", kind: settingString, validate: validateHTTPURL},
+	{attr: "verify_ssl", key: "SOCIAL_AUTH_OIDC_VERIFY_SSL", kind: settingBool},
+}
+
+// oauth2SettingFields are the fields shared by the social authentication
+// categories, name is the key infix, e.g. GITHUB_ORG
+func oauth2SettingFields(name string, extra ...settingField) []settingField {
+	key := func(suffix string) string {
+		return fmt.Sprintf("SOCIAL_AUTH_%s_%s", name, suffix)
+	}
+	fields := []settingField{
+		{attr: "callback_url", key: key("CALLBACK_URL"), kind: settingString, readOnly: true},
+		{attr: "key", key: key("KEY"), kind: settingString},
+		{attr: "secret", key: key("SECRET"), kind: settingString, sensitive: true},
+		{attr: "organization_map", key: key("ORGANIZATION_MAP"), kind: settingJSONMap},
+		{attr: "team_map", key: key("TEAM_MAP"), kind: settingJSONMap},
+	}
+	return append(fields, extra...)
+}
+
+var githubOrgSettingFields = oauth2SettingFields("GITHUB_ORG",
+	settingField{attr: "name", key: "SOCIAL_AUTH_GITHUB_ORG_NAME", kind: settingString,
+		description: "GitHub organization whose members can log in"},
+)
+
+var githubTeamSettingFields = oauth2SettingFields("GITHUB_TEAM",
+	settingField{attr: "team_id", key: "SOCIAL_AUTH_GITHUB_TEAM_ID", kind: settingString,
+		description: "Numeric ID of the GitHub team whose members can log in"},
+)
+
+var azureADOAuth2SettingFields = oauth2SettingFields("AZUREAD_OAUTH2")
+
+var googleOAuth2SettingFields = oauth2SettingFields("GOOGLE_OAUTH2",
+	settingField{attr: "whitelisted_domains", key: "SOCIAL_AUTH_GOOGLE_OAUTH2_WHITELISTED_DOMAINS", kind: settingStringList},
+	settingField{attr: "auth_extra_arguments", key: "SOCIAL_AUTH_GOOGLE_OAUTH2_AUTH_EXTRA_ARGUMENTS", kind: settingJSON},
+)
+
+func resourceSettingsSAMLObject() *schema.Resource {
+	return resourceTypedSettingsObject("saml", samlSettingFields)
+}
+
+func resourceSettingsOIDCObject() *schema.Resource {
+	return resourceTypedSettingsObject("oidc", oidcSettingFields)
+}
+
+func resourceSettingsGithubOrgObject() *schema.Resource {
+	return resourceTypedSettingsObject("github-org", githubOrgSettingFields)
+}
+
+func resourceSettingsGithubTeamObject() *schema.Resource {
+	return resourceTypedSettingsObject("github-team", githubTeamSettingFields)
+}
+
+func resourceSettingsAzureADOAuth2Object() *schema.Resource {
+	return resourceTypedSettingsObject("azuread-oauth2", azureADOAuth2SettingFields)
+}
+
+func resourceSettingsGoogleOAuth2Object() *schema.Resource {
+	return resourceTypedSettingsObject("google-oauth2", googleOAuth2SettingFields)
+}
+
+// validatePEM checks that a certificate or key is PEM encoded
+func validatePEM(v interface{}, k string) (ws []string, errors []error) {
+	value := strings.TrimSpace(v.(string))
+	if value == "" {
+		return
+	}
+	if block, _ := pem.Decode([]byte(value)); block == nil {
+		errors = append(errors, fmt.Errorf("%q must be PEM encoded", k))
+	}
+	return
+}
+
+func validateHTTPURL(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != "" && !strings.HasPrefix(value, "https://") && !strings.HasPrefix(value, "http://") {
+		errors = append(errors, fmt.Errorf("%q must be an http:// or https:// URL, got %q", k, value))
+	}
+	return
+}
@@ -0,0 +1,133 @@
+package awx
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestSettingJSONMap(t *testing.T) {
+	f := settingField{kind: settingJSONMap}
+	config := map[string]interface{}{
+		"Default": `{"users": true, "admins": ["alice"]}`,
+		"Ops":     `{"users": false}`,
+	}
+	value := map[string]interface{}{
+		"Default": map[string]interface{}{"users": true, "admins": []interface{}{"alice"}},
+		"Ops":     map[string]interface{}{"users": false},
+	}
+	if got, err := expandSetting(f, config); err != nil || !reflect.DeepEqual(got, value) {
+		t.Errorf("expandSetting() = %v, %v, want %v", got, err, value)
+	}
+	if _, err := expandSetting(f, map[string]interface{}{"Default": "{users: true}"}); err == nil {
+		t.Errorf("expandSetting() with invalid JSON didn't fail")
+	}
+	value["Dev"] = map[string]interface{}{"users": true}
+	want := map[string]interface{}{
+		"Default": `{"users": true, "admins": ["alice"]}`,
+		"Ops":     `{"users":false}`,
+		"Dev":     `{"users":true}`,
+	}
+	config["Ops"] = `{"users": true}`
+	if got := flattenSetting(f, config, value); !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenSetting() = %v, want %v", got, want)
+	}
+}
+
+func TestValidatePEM(t *testing.T) {
+	cert := "-----BEGIN CERTIFICATE-----\nMIIBszCCAVmgAwIBAgIU\n-----END CERTIFICATE-----\n"
+	for value, valid := range map[string]bool{"": true, cert: true, "MIIBszCCAVmgAwIBAgIU": false} {
+		if _, errs := validatePEM(value, "sp_public_cert"); (len(errs) == 0) != valid {
+			t.Errorf("validatePEM(%q): valid = %t, want %t", value, len(errs) == 0, valid)
+		}
+	}
+}
+
+// TestSettingJSONMapDiff plans organization_map changes, equivalent JSON is
+// suppressed while removed and added entries show up
+func TestSettingJSONMapDiff(t *testing.T) {
+	state := &terraform.InstanceState{ID: "saml", Attributes: map[string]string{
+		"id":                       "saml",
+		"organization_map.%":       "2",
+		"organization_map.Default": `{"users":true}`,
+		"organization_map.Ops":     `{"users":false}`,
+	}}
+	cases := []struct {
+		config map[string]interface{}
+		want   map[string]bool
+	}{
+		{map[string]interface{}{"Default": `{ "users": true }`, "Ops": `{"users": false}`}, nil},
+		{map[string]interface{}{"Default": `{"users": true}`}, map[string]bool{"organization_map.%": true, "organization_map.Ops": true}},
+		{map[string]interface{}{"Default": `{"users": true}`, "Ops": `{"users": false}`, "Dev": `{"users": true}`},
+			map[string]bool{"organization_map.%": true, "organization_map.Dev": true}},
+		{map[string]interface{}{"Default": `{"users": false}`, "Ops": `{"users": false}`}, map[string]bool{"organization_map.Default": true}},
+	}
+	for _, c := range cases {
+		raw := map[string]interface{}{"organization_map": c.config}
+		diff, err := resourceSettingsSAMLObject().Diff(state, &terraform.ResourceConfig{Raw: raw, Config: raw}, nil)
+		if err != nil {
+			t.Fatalf("Diff() with %v: %s", c.config, err)
+		}
+		got := make(map[string]bool)
+		if diff != nil {
+			for k := range diff.Attributes {
+				got[k] = true
+			}
+		}
+		if len(got) != len(c.want) || (len(c.want) > 0 && !reflect.DeepEqual(got, c.want)) {
+			t.Errorf("Diff() with %v changes %v, want %v", c.config, got, c.want)
+		}
+	}
+}
+
+func TestValidateHTTPURL(t *testing.T) {
+	for value, valid := range map[string]bool{
+		"":                             true,
+		"https://example.okta.com/sso": true,
+		"http://idp.example.com":       true,
+		"example.okta.com/sso":         false,
+		"ftp://example.com":            false,
+		"HTTPS://example.okta.com/sso": false,
+	} {
+		if _, errs := validateHTTPURL(value, "url"); (len(errs) == 0) != valid {
+			t.Errorf("validateHTTPURL(%q): valid = %t, want %t", value, len(errs) == 0, valid)
+		}
+	}
+}
+
+// awx_settings_github_org test case
+func TestAccAWXSettingsGithubOrg(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { TestAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSettingsGithubOrgConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_settings_github_org.testacc-github", "name", "example"),
+					resource.TestCheckResourceAttr("awx_settings_github_org.testacc-github", "organization_map.%", "1"),
+					resource.TestCheckResourceAttrSet("awx_settings_github_org.testacc-github", "callback_url"),
+				),
+			},
+			{
+				ResourceName:            "awx_settings_github_org.testacc-github",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret"},
+			},
+		},
+	})
+}
+
+const testAccSettingsGithubOrgConfig = `
+resource "awx_settings_github_org" "testacc-github" {
+	key    = "testacc-key"
+	secret = "testacc-secret"
+	name   = "example"
+	organization_map = {
+		Default = jsonencode({ users = true })
+	}
+}
+`
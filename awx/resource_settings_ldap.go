@@ -0,0 +1,310 @@
+package awx
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+var ldapGroupTypes = []string{
+	"PosixGroupType", "PosixUIDGroupType", "GroupOfNamesType", "GroupOfUniqueNamesType",
+	"ActiveDirectoryGroupType", "OrganizationalRoleGroupType", "MemberDNGroupType",
+	"NestedGroupOfNamesType", "NestedGroupOfUniqueNamesType", "NestedActiveDirectoryGroupType",
+	"NestedOrganizationalRoleGroupType", "NestedMemberDNGroupType",
+}
+
+var ldapSearchScopes = []string{"SCOPE_BASE", "SCOPE_ONELEVEL", "SCOPE_SUBTREE"}
+
+// ldapSettingFields are the settings of one LDAP slot, {slot} is empty for
+// the default server and "1_" to "5_" for the additional ones
+var ldapSettingFields = []settingField{
+	{attr: "server_uri", key: "AUTH_LDAP_{slot}SERVER_URI", kind: settingString, validate: validateLDAPServerURI,
+		description: "URIs of the LDAP servers, separated by commas or spaces. An empty value disables the slot"},
+	{attr: "bind_dn", key: "AUTH_LDAP_{slot}BIND_DN", kind: settingString, validate: validateLDAPDN},
+	{attr: "bind_password", key: "AUTH_LDAP_{slot}BIND_PASSWORD", kind: settingString, sensitive: true},
+	{attr: "start_tls", key: "AUTH_LDAP_{slot}START_TLS", kind: settingBool},
+	{attr: "connection_options", key: "AUTH_LDAP_{slot}CONNECTION_OPTIONS", kind: settingJSON,
+		description: "JSON encoded python-ldap connection options"},
+	{attr: "user_search", key: "AUTH_LDAP_{slot}USER_SEARCH", kind: settingLDAPSearch,
+		description: "Searches finding users, several searches are combined"},
+	{attr: "user_dn_template", key: "AUTH_LDAP_{slot}USER_DN_TEMPLATE", kind: settingString, validate: validateLDAPDNTemplate,
+		description: "DN of users, e.g. uid=%(user)s,ou=users,dc=example,dc=com. Used instead of user_search"},
+	{attr: "user_attr_map", key: "AUTH_LDAP_{slot}USER_ATTR_MAP", kind: settingStringMap,
+		description: "LDAP attributes of the first_name, last_name and email of users"},
+	{attr: "group_search", key: "AUTH_LDAP_{slot}GROUP_SEARCH", kind: settingLDAPSearch, maxItems: 1},
+	{attr: "group_type", key: "AUTH_LDAP_{slot}GROUP_TYPE", kind: settingString,
+		validate: validation.StringInSlice(ldapGroupTypes, false)},
+	{attr: "group_type_params", key: "AUTH_LDAP_{slot}GROUP_TYPE_PARAMS", kind: settingJSON},
+	{attr: "require_group", key: "AUTH_LDAP_{slot}REQUIRE_GROUP", kind: settingString, validate: validateLDAPDN},
+	{attr: "deny_group", key: "AUTH_LDAP_{slot}DENY_GROUP", kind: settingString, validate: validateLDAPDN},
+	{attr: "user_flags_by_group", key: "AUTH_LDAP_{slot}USER_FLAGS_BY_GROUP", kind: settingJSON,
+		description: "JSON encoded map of is_superuser and is_system_auditor to group DNs"},
+	{attr: "organization_map", key: "AUTH_LDAP_{slot}ORGANIZATION_MAP", kind: settingJSONMap},
+	{attr: "team_map", key: "AUTH_LDAP_{slot}TEAM_MAP", kind: settingJSONMap},
+}
+
+// resourceSettingsLDAPObject manages one of the six LDAP server slots of AWX
+func resourceSettingsLDAPObject() *schema.Resource {
+	s := typedSettingsSchema(ldapSettingFields)
+	s["slot"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      0,
+		ForceNew:     true,
+		ValidateFunc: validation.IntBetween(0, 5),
+		Description:  "LDAP server slot, 0 is the default server and 1 to 5 the additional ones",
+	}
+	return &schema.Resource{
+		Create: resourceSettingsLDAPCreate,
+		Read:   resourceSettingsLDAPRead,
+		Delete: resourceSettingsLDAPDelete,
+		Update: resourceSettingsLDAPUpdate,
+
+		Schema: s,
+		Importer: &schema.ResourceImporter{
+			State: resourceSettingsLDAPImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(1 * time.Minute),
+		},
+	}
+}
+
+func ldapSlotPrefix(slot int) string {
+	if slot == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d_", slot)
+}
+
+func resourceSettingsLDAPCreate(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	slot := d.Get("slot").(int)
+	if err := createTypedSettings(d, awx, "ldap", ldapSlotPrefix(slot), ldapSettingFields); err != nil {
+		return err
+	}
+	d.SetId(strconv.Itoa(slot))
+	return resourceSettingsLDAPRead(d, m)
+}
+
+func resourceSettingsLDAPUpdate(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	if err := updateTypedSettings(d, awx, "ldap", ldapSlotPrefix(d.Get("slot").(int)), ldapSettingFields); err != nil {
+		return err
+	}
+	return resourceSettingsLDAPRead(d, m)
+}
+
+func resourceSettingsLDAPRead(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	slot, err := strconv.Atoi(d.Id())
+	if err != nil || slot < 0 || slot > 5 {
+		return fmt.Errorf("Invalid LDAP slot %q, expected 0 to 5", d.Id())
+	}
+	d.Set("slot", slot)
+	return readTypedSettings(d, awx, "ldap", ldapSlotPrefix(slot), ldapSettingFields)
+}
+
+func resourceSettingsLDAPImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	slot, err := strconv.Atoi(d.Id())
+	if err != nil || slot < 0 || slot > 5 {
+		return nil, fmt.Errorf("Invalid LDAP slot %q, expected 0 to 5", d.Id())
+	}
+	if err := importTypedSettings(d, m.(*AWXClient), "ldap", ldapSlotPrefix(slot), ldapSettingFields); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceSettingsLDAPDelete(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	return deleteTypedSettings(d, awx, "ldap", ldapSlotPrefix(d.Get("slot").(int)), ldapSettingFields)
+}
+
+func ldapSearchResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"base_dn": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateLDAPDN,
+			},
+			"scope": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "SCOPE_SUBTREE",
+				ValidateFunc: validation.StringInSlice(ldapSearchScopes, false),
+			},
+			"filter": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateLDAPFilter,
+			},
+		},
+	}
+}
+
+// expandLDAPSearch converts search blocks into the AWX format, a single
+// search is [base_dn, scope, filter] and several are a list of those
+func expandLDAPSearch(blocks []interface{}) interface{} {
+	var searches []interface{}
+	for _, b := range blocks {
+		block := b.(map[string]interface{})
+		searches = append(searches, []interface{}{block["base_dn"], block["scope"], block["filter"]})
+	}
+	switch len(searches) {
+	case 0:
+		return []interface{}{}
+	case 1:
+		return searches[0]
+	}
+	return searches
+}
+
+func flattenLDAPSearch(value interface{}) []interface{} {
+	list, ok := value.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil
+	}
+	searches := [][]interface{}{list}
+	if _, nested := list[0].([]interface{}); nested {
+		searches = nil
+		for _, s := range list {
+			if search, ok := s.([]interface{}); ok {
+				searches = append(searches, search)
+			}
+		}
+	}
+	var result []interface{}
+	for _, s := range searches {
+		if len(s) != 3 {
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			"base_dn": fmt.Sprintf("%v", s[0]),
+			"scope":   fmt.Sprintf("%v", s[1]),
+			"filter":  fmt.Sprintf("%v", s[2]),
+		})
+	}
+	return result
+}
+
+var ldapAttributeType = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9-]*|[0-9]+(\.[0-9]+)*)$`)
+
+// splitUnescaped splits s on sep, ignoring separators escaped with a backslash
+func splitUnescaped(s string, sep rune) []string {
+	var parts []string
+	var current strings.Builder
+	escaped := false
+	for _, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == sep:
+			parts = append(parts, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	return append(parts, current.String())
+}
+
+// parseLDAPDN checks the syntax of a distinguished name like cn=admins,dc=example,dc=com
+func parseLDAPDN(dn string) error {
+	if strings.TrimSpace(dn) == "" {
+		return fmt.Errorf("empty DN")
+	}
+	for _, rdn := range splitUnescaped(dn, ',') {
+		for _, ava := range splitUnescaped(rdn, '+') {
+			parts := strings.SplitN(ava, "=", 2)
+			if len(parts) != 2 {
+				return fmt.Errorf("%q is not an attribute=value pair", strings.TrimSpace(ava))
+			}
+			if attr := strings.TrimSpace(parts[0]); !ldapAttributeType.MatchString(attr) {
+				return fmt.Errorf("%q is not a valid attribute type", attr)
+			}
+			if strings.TrimSpace(parts[1]) == "" {
+				return fmt.Errorf("missing value for %s", strings.TrimSpace(parts[0]))
+			}
+		}
+	}
+	return nil
+}
+
+func validateLDAPDN(v interface{}, k string) (ws []string, errors []error) {
+	if dn := v.(string); dn != "" {
+		if err := parseLDAPDN(dn); err != nil {
+			errors = append(errors, fmt.Errorf("%q is not a valid DN: %s", k, err))
+		}
+	}
+	return
+}
+
+func validateLDAPDNTemplate(v interface{}, k string) (ws []string, errors []error) {
+	template := v.(string)
+	if template == "" {
+		return
+	}
+	if !strings.Contains(template, "%(user)s") {
+		errors = append(errors, fmt.Errorf("%q must contain the %%(user)s placeholder", k))
+		return
+	}
+	if err := parseLDAPDN(strings.Replace(template, "%(user)s", "user", -1)); err != nil {
+		errors = append(errors, fmt.Errorf("%q is not a valid DN template: %s", k, err))
+	}
+	return
+}
+
+// validateLDAPFilter checks that a search filter is parenthesized and balanced
+func validateLDAPFilter(v interface{}, k string) (ws []string, errors []error) {
+	filter := strings.TrimSpace(v.(string))
+	if !strings.HasPrefix(filter, "(") || !strings.HasSuffix(filter, ")") {
+		errors = append(errors, fmt.Errorf("%q must be enclosed in parentheses, e.g. (uid=%%(user)s)", k))
+		return
+	}
+	depth := 0
+	escaped := false
+	for i, r := range filter {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+			if depth == 0 && i != len(filter)-1 {
+				errors = append(errors, fmt.Errorf("%q has several top level filters, combine them with & or |", k))
+				return
+			}
+		}
+		if depth < 0 {
+			break
+		}
+	}
+	if depth != 0 {
+		errors = append(errors, fmt.Errorf("%q has unbalanced parentheses", k))
+	}
+	return
+}
+
+func validateLDAPServerURI(v interface{}, k string) (ws []string, errors []error) {
+	for _, uri := range strings.FieldsFunc(v.(string), func(r rune) bool { return r == ',' || r == ' ' }) {
+		if !strings.HasPrefix(uri, "ldap://") && !strings.HasPrefix(uri, "ldaps://") {
+			errors = append(errors, fmt.Errorf("%q must only contain ldap:// or ldaps:// URIs, got %q", k, uri))
+		}
+	}
+	return
+}
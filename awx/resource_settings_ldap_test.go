@@ -0,0 +1,160 @@
+package awx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestLDAPSearch(t *testing.T) {
+	users := map[string]interface{}{"base_dn": "ou=users,dc=example,dc=com", "scope": "SCOPE_SUBTREE", "filter": "(uid=%(user)s)"}
+	admins := map[string]interface{}{"base_dn": "ou=admins,dc=example,dc=com", "scope": "SCOPE_ONELEVEL", "filter": "(uid=%(user)s)"}
+	cases := []struct {
+		blocks []interface{}
+		value  interface{}
+	}{
+		{nil, []interface{}{}},
+		{[]interface{}{users}, []interface{}{"ou=users,dc=example,dc=com", "SCOPE_SUBTREE", "(uid=%(user)s)"}},
+		{[]interface{}{users, admins}, []interface{}{
+			[]interface{}{"ou=users,dc=example,dc=com", "SCOPE_SUBTREE", "(uid=%(user)s)"},
+			[]interface{}{"ou=admins,dc=example,dc=com", "SCOPE_ONELEVEL", "(uid=%(user)s)"},
+		}},
+	}
+	for _, c := range cases {
+		value := expandLDAPSearch(c.blocks)
+		if !reflect.DeepEqual(value, c.value) {
+			t.Errorf("expandLDAPSearch(%v) = %v, want %v", c.blocks, value, c.value)
+		}
+		if got := flattenLDAPSearch(value); !reflect.DeepEqual(got, c.blocks) {
+			t.Errorf("flattenLDAPSearch(%v) = %v, want %v", value, got, c.blocks)
+		}
+	}
+}
+
+func TestValidateLDAP(t *testing.T) {
+	cases := []struct {
+		validate func(interface{}, string) ([]string, []error)
+		value    string
+		valid    bool
+	}{
+		{validateLDAPDN, "", true},
+		{validateLDAPDN, "cn=awx,ou=services,dc=example,dc=com", true},
+		{validateLDAPDN, `cn=Doe\, John+uid=jdoe,dc=example`, true},
+		{validateLDAPDN, "2.5.4.3=awx,dc=example", true},
+		{validateLDAPDN, "awx,dc=example", false},
+		{validateLDAPDN, "cn=,dc=example", false},
+		{validateLDAPDN, "c n=awx", false},
+		{validateLDAPDNTemplate, "uid=%(user)s,ou=users,dc=example,dc=com", true},
+		{validateLDAPDNTemplate, "uid=user,ou=users,dc=example,dc=com", false},
+		{validateLDAPDNTemplate, "%(user)s", false},
+		{validateLDAPFilter, "(uid=%(user)s)", true},
+		{validateLDAPFilter, "(&(objectClass=person)(|(uid=a)(uid=b\\29)))", true},
+		{validateLDAPFilter, "uid=%(user)s", false},
+		{validateLDAPFilter, "(uid=a)(uid=b)", false},
+		{validateLDAPFilter, "(&(uid=a)", false},
+		{validateLDAPServerURI, "ldap://a.example.com, ldaps://b.example.com", true},
+		{validateLDAPServerURI, "", true},
+		{validateLDAPServerURI, "https://a.example.com", false},
+	}
+	for _, c := range cases {
+		_, errs := c.validate(c.value, "attr")
+		if valid := len(errs) == 0; valid != c.valid {
+			t.Errorf("validating %q: valid = %t, want %t (%v)", c.value, valid, c.valid, errs)
+		}
+	}
+}
+
+// awx_settings_ldap test case
+// TestLDAPSettingsRemoved removes deny_group from the configuration, its
+// setting is reset to the default
+func TestLDAPSettingsRemoved(t *testing.T) {
+	var patched map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "OPTIONS":
+			w.Write([]byte(`{"actions": {"PUT": {"AUTH_LDAP_DENY_GROUP": {"type": "string", "default": null}}}}`))
+		case "PATCH":
+			json.NewDecoder(r.Body).Decode(&patched)
+			w.Write([]byte(`{}`))
+		default:
+			w.Write([]byte(`{"AUTH_LDAP_DENY_GROUP": null}`))
+		}
+	}))
+	defer server.Close()
+
+	awx := (&Config{Endpoint: server.URL}).Client()
+	state := &terraform.InstanceState{ID: "0", Attributes: map[string]string{
+		"id":         "0",
+		"slot":       "0",
+		"deny_group": "cn=denied,dc=example,dc=com",
+	}}
+	raw := map[string]interface{}{"slot": 0}
+	r := resourceSettingsLDAPObject()
+	diff, err := r.Diff(state, &terraform.ResourceConfig{Raw: raw, Config: raw}, awx)
+	if err != nil {
+		t.Fatalf("Diff(): %s", err)
+	}
+	state, err = r.Apply(state, diff, awx)
+	if err != nil {
+		t.Fatalf("Apply(): %s", err)
+	}
+	if v, ok := patched["AUTH_LDAP_DENY_GROUP"]; !ok || v != nil {
+		t.Errorf("PATCH %v, want AUTH_LDAP_DENY_GROUP reset to null", patched)
+	}
+	if v := state.Attributes["deny_group"]; v != "" {
+		t.Errorf("deny_group = %q after apply, want it unset", v)
+	}
+}
+
+func TestAccAWXSettingsLDAP(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { TestAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSettingsLDAPConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_settings_ldap.testacc-ldap", "server_uri", "ldap://ldap.example.com"),
+					resource.TestCheckResourceAttr("awx_settings_ldap.testacc-ldap", "user_search.#", "2"),
+					resource.TestCheckResourceAttr("awx_settings_ldap.testacc-ldap", "group_search.0.scope", "SCOPE_SUBTREE"),
+				),
+			},
+			{
+				ResourceName:            "awx_settings_ldap.testacc-ldap",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"bind_password"},
+			},
+		},
+	})
+}
+
+const testAccSettingsLDAPConfig = `
+resource "awx_settings_ldap" "testacc-ldap" {
+	slot          = 3
+	server_uri    = "ldap://ldap.example.com"
+	bind_dn       = "cn=awx,dc=example,dc=com"
+	bind_password = "secret"
+	group_type    = "GroupOfNamesType"
+
+	user_search {
+		base_dn = "ou=users,dc=example,dc=com"
+		filter  = "(uid=%(user)s)"
+	}
+	user_search {
+		base_dn = "ou=admins,dc=example,dc=com"
+		scope   = "SCOPE_ONELEVEL"
+		filter  = "(uid=%(user)s)"
+	}
+	group_search {
+		base_dn = "ou=groups,dc=example,dc=com"
+		filter  = "(objectClass=groupOfNames)"
+	}
+}
+`
@@ -0,0 +1,96 @@
+package awx
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestReadSettingsValues(t *testing.T) {
+	managed := map[string]interface{}{
+		"SESSION_COOKIE_AGE":        "1800",
+		"AUTH_BASIC_ENABLED":        "true",
+		"LOG_AGGREGATOR_PASSWORD":   `"secret"`,
+		"AWX_ISOLATION_SHOW_PATHS":  `[ "/opt/a" ]`,
+		"LOG_AGGREGATOR_LOGGERS":    `["awx"]`,
+		"REMOVED_IN_NEWER_VERSIONS": "1",
+	}
+	current := map[string]interface{}{
+		"SESSION_COOKIE_AGE":       float64(1800),
+		"AUTH_BASIC_ENABLED":       false,
+		"LOG_AGGREGATOR_PASSWORD":  encryptedSetting,
+		"AWX_ISOLATION_SHOW_PATHS": []interface{}{"/opt/a"},
+		"LOG_AGGREGATOR_LOGGERS":   []interface{}{"awx", "activity_stream"},
+	}
+	want := map[string]interface{}{
+		"SESSION_COOKIE_AGE":       "1800",
+		"AUTH_BASIC_ENABLED":       "false",
+		"LOG_AGGREGATOR_PASSWORD":  `"secret"`,
+		"AWX_ISOLATION_SHOW_PATHS": `[ "/opt/a" ]`,
+		"LOG_AGGREGATOR_LOGGERS":   `["awx","activity_stream"]`,
+	}
+	if got := readSettingsValues(managed, current); !reflect.DeepEqual(got, want) {
+		t.Errorf("readSettingsValues() = %v, want %v", got, want)
+	}
+}
+
+func TestSuppressEquivalentSettingValue(t *testing.T) {
+	cases := []struct {
+		old, new string
+		equal    bool
+	}{
+		{`{"a": 1, "b": [1, 2]}`, `{"b":[1,2],"a":1}`, true},
+		{`["a", "b"]`, `["b", "a"]`, false},
+		{"1800", "1800.0", true},
+		{"", "1", false},
+	}
+	for _, c := range cases {
+		if got := suppressEquivalentSettingValue("settings.KEY", c.old, c.new, nil); got != c.equal {
+			t.Errorf("suppressEquivalentSettingValue(%q, %q) = %t, want %t", c.old, c.new, got, c.equal)
+		}
+	}
+}
+
+func TestDeclaredSettings(t *testing.T) {
+	settings := map[string]interface{}{"SESSION_COOKIE_AGE": "1800"}
+	sensitive := map[string]interface{}{"LOG_AGGREGATOR_PASSWORD": `"secret"`}
+	want := map[string]interface{}{"SESSION_COOKIE_AGE": float64(1800), "LOG_AGGREGATOR_PASSWORD": "secret"}
+	if got, err := declaredSettings(settings, sensitive); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("declaredSettings() = %v, %v, want %v", got, err, want)
+	}
+	sensitive["LOG_AGGREGATOR_PASSWORD"] = "secret"
+	if _, err := declaredSettings(settings, sensitive); err == nil {
+		t.Errorf("declaredSettings() with invalid JSON didn't fail")
+	}
+	if _, err := declaredSettings(settings, map[string]interface{}{"SESSION_COOKIE_AGE": "900"}); err == nil {
+		t.Errorf("declaredSettings() with a key in both maps didn't fail")
+	}
+}
+
+// awx_settings test case
+func TestAccAWXSettings(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { TestAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSettingsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_settings.testacc-system", "settings.SESSION_COOKIE_AGE", "1800"),
+					resource.TestCheckResourceAttr("awx_settings.testacc-system", "settings.%", "2"),
+				),
+			},
+		},
+	})
+}
+
+const testAccSettingsConfig = `
+resource "awx_settings" "testacc-system" {
+	category = "authentication"
+	settings = {
+		SESSION_COOKIE_AGE = jsonencode(1800)
+		SESSIONS_PER_USER  = jsonencode(5)
+	}
+}
+`
@@ -0,0 +1,118 @@
+package awx
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceTeamMembershipObject manages the complete set of users of a team.
+// It is authoritative, users added outside of Terraform are removed on apply,
+// so it must not be combined with awx_team_member on the same team.
+func resourceTeamMembershipObject() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTeamMembershipCreate,
+		Read:   resourceTeamMembershipRead,
+		Delete: resourceTeamMembershipDelete,
+		Update: resourceTeamMembershipUpdate,
+
+		Schema: map[string]*schema.Schema{
+			"team_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_ids": &schema.Schema{
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Required:    true,
+				Description: "Ids of every user member of the team",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(1 * time.Minute),
+		},
+	}
+}
+
+// resourceTeamMemberObject makes a single user member of a team, leaving the
+// other members alone
+func resourceTeamMemberObject() *schema.Resource {
+	return resourceUserLinkObject("team_id", "teams", "users")
+}
+
+func teamUsersEndpoint(teamID int) string {
+	return fmt.Sprintf("/api/v2/teams/%d/users/", teamID)
+}
+
+func resourceTeamMembershipCreate(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	teamID := d.Get("team_id").(int)
+	current, err := awx.apiListIDs(teamUsersEndpoint(teamID), nil)
+	if err != nil {
+		return err
+	}
+	old := schema.NewSet(schema.HashInt, intsToInterfaces(current))
+	if err := awx.apiUpdateAssociations(teamUsersEndpoint(teamID), old, d.Get("user_ids").(*schema.Set)); err != nil {
+		return err
+	}
+	d.SetId(strconv.Itoa(teamID))
+	return resourceTeamMembershipRead(d, m)
+}
+
+func resourceTeamMembershipUpdate(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	teamID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+	if d.HasChange("user_ids") {
+		o, n := d.GetChange("user_ids")
+		if err := awx.apiUpdateAssociations(teamUsersEndpoint(teamID), o.(*schema.Set), n.(*schema.Set)); err != nil {
+			return err
+		}
+	}
+	return resourceTeamMembershipRead(d, m)
+}
+
+func resourceTeamMembershipRead(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	teamID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Team %s not found", d.Id())
+	}
+	users, err := awx.apiListIDs(teamUsersEndpoint(teamID), nil)
+	if err != nil {
+		if isAPINotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	d.Set("team_id", teamID)
+	d.Set("user_ids", users)
+	return nil
+}
+
+func resourceTeamMembershipDelete(d *schema.ResourceData, m interface{}) error {
+	awx := m.(*AWXClient)
+	teamID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+	for _, v := range d.Get("user_ids").(*schema.Set).List() {
+		if err := awx.apiDisassociate(teamUsersEndpoint(teamID), v.(int)); err != nil {
+			return fmt.Errorf("Failed to remove user %d from team %d: %s", v.(int), teamID, err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,78 @@
+package awx
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+// awx_team_membership and awx_team_member test case
+func TestAccAWXTeamMembership(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { TestAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTeamMembershipConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awx_team_membership.testacc-membership_1", "user_ids.#", "2"),
+					resource.TestCheckResourceAttrPair("awx_team_member.testacc-member_1", "user_id",
+						"awx_user.testacc-membership_3", "id"),
+				),
+			},
+			{
+				ResourceName:      "awx_team_membership.testacc-membership_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "awx_team_member.testacc-member_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccTeamMembershipConfig = `
+resource "awx_team" "testacc-membership_1" {
+	name = "testacc-membership_1"
+	organization_id = "1"
+}
+
+resource "awx_team" "testacc-membership_2" {
+	name = "testacc-membership_2"
+	organization_id = "1"
+}
+
+resource "awx_user" "testacc-membership_1" {
+	username = "testacc-membership_1"
+	password = "password"
+	email = "testacc-membership_1@test.td"
+}
+
+resource "awx_user" "testacc-membership_2" {
+	username = "testacc-membership_2"
+	password = "password"
+	email = "testacc-membership_2@test.td"
+}
+
+resource "awx_user" "testacc-membership_3" {
+	username = "testacc-membership_3"
+	password = "password"
+	email = "testacc-membership_3@test.td"
+}
+
+resource "awx_team_membership" "testacc-membership_1" {
+	team_id = "${awx_team.testacc-membership_1.id}"
+	user_ids = [
+		"${awx_user.testacc-membership_1.id}",
+		"${awx_user.testacc-membership_2.id}",
+	]
+}
+
+resource "awx_team_member" "testacc-member_1" {
+	team_id = "${awx_team.testacc-membership_2.id}"
+	user_id = "${awx_user.testacc-membership_3.id}"
+}
+`
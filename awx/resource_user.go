@@ -25,8 +25,9 @@ func resourceUserObject() *schema.Resource {
 
 			"password": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Password of this user.",
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password of this user, only sent on create or when changed. Leave unset for externally authenticated users.",
 			},
 
 			"email": &schema.Schema{
@@ -61,7 +62,20 @@ func resourceUserObject() *schema.Resource {
 				Default:     false,
 				Description: "The user is a system administrator.",
 			},
+
+			"auth_source": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "How the user authenticates: local, ldap, social or enterprise.",
+			},
+
+			"ldap_dn": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Distinguished name of LDAP users.",
+			},
 		},
+		CustomizeDiff: resourceUserCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -75,7 +89,7 @@ func resourceUserObject() *schema.Resource {
 }
 
 func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.UserService
 	_, res, err := awxService.ListUsers(map[string]string{
 		"username": d.Get("username").(string)})
@@ -87,15 +101,9 @@ func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
 			d.Get("username").(string))
 	}
 
-	result, err := awxService.CreateUser(map[string]interface{}{
-		"username":          d.Get("username").(string),
-		"password":          d.Get("password").(string),
-		"email":             d.Get("email").(string),
-		"first_name":        d.Get("first_name").(string),
-		"last_name":         d.Get("last_name").(string),
-		"is_superuser":      d.Get("is_superuser").(bool),
-		"is_system_auditor": d.Get("is_system_auditor").(bool),
-	}, map[string]string{})
+	payload := userPayload(d)
+	payload["password"] = d.Get("password").(string)
+	result, err := awxService.CreateUser(payload, map[string]string{})
 	if err != nil {
 		return err
 	}
@@ -105,7 +113,7 @@ func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.UserService
 	_, res, err := awxService.ListUsers(map[string]string{
 		"id": d.Id()},
@@ -114,22 +122,18 @@ func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 	if len(res.Results) == 0 {
-		return fmt.Errorf("User with name %s doesn't exists",
-			d.Get("username").(string))
+		return fmt.Errorf("User with id %s doesn't exist", d.Id())
 	}
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
 		return err
 	}
-	_, err = awxService.UpdateUser(id, map[string]interface{}{
-		"username":          d.Get("username").(string),
-		"password":          d.Get("password").(string),
-		"email":             d.Get("email").(string),
-		"first_name":        d.Get("first_name").(string),
-		"last_name":         d.Get("last_name").(string),
-		"is_superuser":      d.Get("is_superuser").(bool),
-		"is_system_auditor": d.Get("is_system_auditor").(bool),
-	}, map[string]string{})
+	// AWX keeps the current password when none is sent
+	payload := userPayload(d)
+	if d.HasChange("password") && d.Get("password").(string) != "" {
+		payload["password"] = d.Get("password").(string)
+	}
+	_, err = awxService.UpdateUser(id, payload, map[string]string{})
 	if err != nil {
 		return err
 	}
@@ -138,14 +142,15 @@ func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceUserRead(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.UserService
 	_, res, err := awxService.ListUsers(map[string]string{
-		"username": d.Get("username").(string)})
+		"id": d.Id()})
 	if err != nil {
 		return err
 	}
 	if len(res.Results) == 0 {
+		d.SetId("")
 		return nil
 	}
 	d = setUserResourceData(d, res.Results[0])
@@ -153,21 +158,21 @@ func resourceUserRead(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceUserDelete(d *schema.ResourceData, m interface{}) error {
-	awx := m.(*awxgo.AWX)
+	awx := m.(*AWXClient)
 	awxService := awx.UserService
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
 		return err
 	}
 	_, res, err := awxService.ListUsers(map[string]string{
-		"username": d.Get("username").(string)})
+		"id": d.Id()})
+	if err != nil {
+		return err
+	}
 	if len(res.Results) == 0 {
 		d.SetId("")
 		return nil
 	}
-	if err != nil {
-		return err
-	}
 	if _, err = awxService.DeleteUser(id); err != nil {
 		return err
 	}
@@ -175,13 +180,58 @@ func resourceUserDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+func userPayload(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"username":          d.Get("username").(string),
+		"email":             d.Get("email").(string),
+		"first_name":        d.Get("first_name").(string),
+		"last_name":         d.Get("last_name").(string),
+		"is_superuser":      d.Get("is_superuser").(bool),
+		"is_system_auditor": d.Get("is_system_auditor").(bool),
+	}
+}
+
+// resourceUserCustomizeDiff requires a password for new users and refuses
+// passwords for users authenticated outside of AWX
+func resourceUserCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	// A password computed by another resource is only known on apply
+	if !d.NewValueKnown("password") {
+		return nil
+	}
+	password := d.Get("password").(string)
+	if d.Id() == "" {
+		if password == "" {
+			return fmt.Errorf("password is required to create user %s, externally authenticated users are created on their first login and can be imported",
+				d.Get("username").(string))
+		}
+		return nil
+	}
+	if source := d.Get("auth_source").(string); d.HasChange("password") && password != "" && source != "" && source != "local" {
+		return fmt.Errorf("User %s is authenticated by %s, its password can't be set", d.Get("username").(string), source)
+	}
+	return nil
+}
+
+// userAuthSource tells how a user authenticates, AWX flags external users with
+// ldap_dn or external_account
+func userAuthSource(r *awxgo.User) string {
+	if r.LdapDn != "" {
+		return "ldap"
+	}
+	if account, ok := r.ExternalAccount.(string); ok && account != "" {
+		return account
+	}
+	return "local"
+}
+
 func setUserResourceData(d *schema.ResourceData, r *awxgo.User) *schema.ResourceData {
 	d.Set("username", r.Username)
-	d.Set("password", d.Get("password").(string))
 	d.Set("email", r.Email)
 	d.Set("first_name", r.FirstName)
 	d.Set("last_name", r.LastName)
 	d.Set("is_superuser", r.IsSuperUser)
 	d.Set("is_system_auditor", r.IsSystemAuditor)
+	d.Set("auth_source", userAuthSource(r))
+	d.Set("ldap_dn", r.LdapDn)
 	return d
 }
@@ -0,0 +1,106 @@
+package awx
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// userLink describes the related users endpoint of an organization or team
+type userLink struct {
+	parentAttr string
+	collection string
+	related    string
+}
+
+func (l userLink) endpoint(parentID int) string {
+	return fmt.Sprintf("/api/v2/%s/%d/%s/", l.collection, parentID, l.related)
+}
+
+// resourceUserLinkObject links a user to an organization or team through the
+// related endpoint of the parent object. The ID is <parent id>:<user id>.
+func resourceUserLinkObject(parentAttr, collection, related string) *schema.Resource {
+	link := userLink{parentAttr: parentAttr, collection: collection, related: related}
+	return &schema.Resource{
+		Create: func(d *schema.ResourceData, m interface{}) error {
+			return resourceUserLinkCreate(d, m, link)
+		},
+		Read: func(d *schema.ResourceData, m interface{}) error {
+			return resourceUserLinkRead(d, m, link)
+		},
+		Delete: func(d *schema.ResourceData, m interface{}) error {
+			return resourceUserLinkDelete(d, m, link)
+		},
+
+		Schema: map[string]*schema.Schema{
+			parentAttr: &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(1 * time.Minute),
+		},
+	}
+}
+
+func resourceUserLinkCreate(d *schema.ResourceData, m interface{}, link userLink) error {
+	awx := m.(*AWXClient)
+	parentID := d.Get(link.parentAttr).(int)
+	userID := d.Get("user_id").(int)
+	endpoint := link.endpoint(parentID)
+	if err := awx.apiAssociate(endpoint, userID); err != nil {
+		return fmt.Errorf("Failed to add user %d to %s: %s", userID, endpoint, err)
+	}
+	d.SetId(compositeID(parentID, userID))
+	return resourceUserLinkRead(d, m, link)
+}
+
+func resourceUserLinkRead(d *schema.ResourceData, m interface{}, link userLink) error {
+	awx := m.(*AWXClient)
+	parentID, userID, err := parseCompositeID(d.Id())
+	if err != nil {
+		return err
+	}
+	ok, err := awx.apiIsAssociated(link.endpoint(parentID), userID)
+	if err != nil {
+		if isAPINotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	if !ok {
+		d.SetId("")
+		return nil
+	}
+	d.Set(link.parentAttr, parentID)
+	d.Set("user_id", userID)
+	return nil
+}
+
+func resourceUserLinkDelete(d *schema.ResourceData, m interface{}, link userLink) error {
+	awx := m.(*AWXClient)
+	parentID, userID, err := parseCompositeID(d.Id())
+	if err != nil {
+		return err
+	}
+	endpoint := link.endpoint(parentID)
+	if err := awx.apiDisassociate(endpoint, userID); err != nil {
+		return fmt.Errorf("Failed to remove user %d from %s: %s", userID, endpoint, err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -4,10 +4,34 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform/config/hcl2shim"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
+// TestUserPasswordPlan plans new users, a password only known on apply
+// must not be reported as missing
+func TestUserPasswordPlan(t *testing.T) {
+	for password, valid := range map[string]bool{
+		"secret":                      true,
+		hcl2shim.UnknownVariableValue: true,
+		"":                            false,
+	} {
+		raw := map[string]interface{}{"username": "testacc-user", "email": "testacc@test.td"}
+		if password != "" {
+			raw["password"] = password
+		}
+		c := &terraform.ResourceConfig{Raw: raw, Config: raw}
+		if password == hcl2shim.UnknownVariableValue {
+			c.ComputedKeys = []string{"password"}
+		}
+		_, err := resourceUserObject().Diff(nil, c, nil)
+		if (err == nil) != valid {
+			t.Errorf("Diff() with password %q: error %v, want valid = %t", password, err, valid)
+		}
+	}
+}
+
 // awx_user test case
 func TestAccAWXUser(t *testing.T) {
 	resource.Test(t, resource.TestCase{
@@ -23,6 +47,21 @@ func TestAccAWXUser(t *testing.T) {
 					testAccCheckStateUser("last_name", "Medda"),
 					testAccCheckStateUser("is_superuser", "true"),
 					testAccCheckStateUser("email", "medda.mauro@test.td"),
+					testAccCheckStateUser("auth_source", "local"),
+				),
+			},
+			{
+				ResourceName:            "awx_user.testacc-user_1",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+			{
+				Config: testAccUserRenamedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStateUser("username", "mmedda"),
+					resource.TestCheckResourceAttrPair("data.awx_user.testacc-user_1", "id",
+						"awx_user.testacc-user_1", "id"),
 				),
 			},
 		},
@@ -59,3 +98,18 @@ resource "awx_user" "testacc-user_1" {
 	email = "medda.mauro@test.td"
   }
 `
+
+const testAccUserRenamedConfig = `
+resource "awx_user" "testacc-user_1" {
+	username = "mmedda"
+	password = "password"
+	first_name = "Mauro"
+	last_name = "Medda"
+	is_superuser = true
+	email = "medda.mauro@test.td"
+  }
+
+data "awx_user" "testacc-user_1" {
+	email = "${awx_user.testacc-user_1.email}"
+  }
+`
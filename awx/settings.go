@@ -0,0 +1,103 @@
+package awx
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// encryptedSetting is returned by AWX in place of the value of secret settings
+const encryptedSetting = "$encrypted$"
+
+// settingMetadata describes one setting of a category, as listed by the
+// OPTIONS of the category endpoint
+type settingMetadata struct {
+	Type          string      `json:"type"`
+	Default       interface{} `json:"default"`
+	DefinedInFile bool        `json:"defined_in_file"`
+}
+
+type settingsOptions struct {
+	Actions struct {
+		PUT map[string]settingMetadata `json:"PUT"`
+	} `json:"actions"`
+}
+
+func settingsEndpoint(category string) string {
+	return fmt.Sprintf("/api/v2/settings/%s/", category)
+}
+
+// getSettings returns every setting of the category
+func getSettings(awx *AWXClient, category string) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	if err := awx.apiGet(settingsEndpoint(category), &result, nil); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func getSettingsMetadata(awx *AWXClient, category string) (map[string]settingMetadata, error) {
+	result := new(settingsOptions)
+	if err := awx.apiOptions(settingsEndpoint(category), result); err != nil {
+		return nil, err
+	}
+	return result.Actions.PUT, nil
+}
+
+// updateSettings sets values and restores the default of the removed keys in
+// a single PATCH. Settings defined in a settings file can't be changed through
+// the api, they are refused when set and left alone when removed.
+func updateSettings(awx *AWXClient, category string, values map[string]interface{}, removed []string) error {
+	meta, err := getSettingsMetadata(awx, category)
+	if err != nil {
+		return err
+	}
+	payload := make(map[string]interface{})
+	for k, v := range values {
+		m, ok := meta[k]
+		if !ok {
+			return fmt.Errorf("Unknown setting %s in category %s", k, category)
+		}
+		if m.DefinedInFile {
+			return fmt.Errorf("Setting %s is defined in a settings file, it can't be changed through the API", k)
+		}
+		payload[k] = v
+	}
+	for _, k := range removed {
+		if m, ok := meta[k]; ok && !m.DefinedInFile {
+			payload[k] = m.Default
+		}
+	}
+	if len(payload) == 0 {
+		return nil
+	}
+	return awx.apiPatch(settingsEndpoint(category), payload, nil)
+}
+
+// settingJSONEqual reports whether the JSON document s encodes v
+func settingJSONEqual(s string, v interface{}) bool {
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(s), &parsed); err != nil {
+		return false
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return false
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(b, &normalized); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(parsed, normalized)
+}
+
+// settingsKeys returns the sorted keys of a settings map
+func settingsKeys(m map[string]interface{}) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
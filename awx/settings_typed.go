@@ -0,0 +1,347 @@
+package awx
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// Typed settings resources expose the settings of a category as attributes.
+// Attributes left unset keep the AWX value and are only read, destroying the
+// resource restores the default of every setting it covers.
+
+type settingKind int
+
+const (
+	settingString settingKind = iota
+	settingBool
+	settingStringList
+	settingStringMap
+	settingJSON
+	settingJSONMap
+	settingLDAPSearch
+)
+
+// settingField maps one attribute to a setting key, {slot} in the key is
+// replaced by the key prefix of the resource. Only the configured attributes
+// are managed, removing one restores the default of its setting. Read only
+// settings like callback URLs are computed and never reset.
+type settingField struct {
+	attr        string
+	key         string
+	kind        settingKind
+	sensitive   bool
+	readOnly    bool
+	maxItems    int
+	validate    schema.SchemaValidateFunc
+	description string
+}
+
+func (f settingField) settingKey(prefix string) string {
+	return strings.Replace(f.key, "{slot}", prefix, 1)
+}
+
+func (f settingField) schema() *schema.Schema {
+	s := &schema.Schema{
+		Optional:     !f.readOnly,
+		Computed:     f.readOnly,
+		Sensitive:    f.sensitive,
+		ValidateFunc: f.validate,
+		Description:  f.description,
+	}
+	switch f.kind {
+	case settingBool:
+		s.Type = schema.TypeBool
+	case settingStringList:
+		s.Type = schema.TypeList
+		s.Elem = &schema.Schema{Type: schema.TypeString}
+	case settingStringMap:
+		s.Type = schema.TypeMap
+		s.Elem = &schema.Schema{Type: schema.TypeString}
+	case settingJSON:
+		s.Type = schema.TypeString
+		s.ValidateFunc = validation.ValidateJsonString
+		s.DiffSuppressFunc = suppressEquivalentSettingValue
+	case settingJSONMap:
+		s.Type = schema.TypeMap
+		s.Elem = &schema.Schema{Type: schema.TypeString}
+		s.ValidateFunc = validateSettingsValues
+		s.DiffSuppressFunc = suppressEquivalentSettingMapEntry
+	case settingLDAPSearch:
+		s.Type = schema.TypeList
+		s.MaxItems = f.maxItems
+		s.Elem = ldapSearchResource()
+	default:
+		s.Type = schema.TypeString
+	}
+	return s
+}
+
+// suppressEquivalentSettingMapEntry hides the entries of a settingJSONMap
+// whose JSON value is unchanged. The map length and the entries being added
+// or removed are always diffed, so removing an entry removes it from AWX.
+func suppressEquivalentSettingMapEntry(k, old, new string, d *schema.ResourceData) bool {
+	if strings.HasSuffix(k, ".%") || old == "" || new == "" {
+		return false
+	}
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(new), &parsed); err != nil {
+		return false
+	}
+	return settingJSONEqual(old, parsed)
+}
+
+func typedSettingsSchema(fields []settingField) map[string]*schema.Schema {
+	result := make(map[string]*schema.Schema)
+	for _, f := range fields {
+		result[f.attr] = f.schema()
+	}
+	return result
+}
+
+// expandSetting converts an attribute value into the setting value
+func expandSetting(f settingField, v interface{}) (interface{}, error) {
+	switch f.kind {
+	case settingJSON:
+		var parsed interface{}
+		if s := v.(string); s != "" {
+			if err := json.Unmarshal([]byte(s), &parsed); err != nil {
+				return nil, fmt.Errorf("%s is not valid JSON: %s", f.attr, err)
+			}
+		}
+		return parsed, nil
+	case settingStringMap:
+		return v.(map[string]interface{}), nil
+	case settingJSONMap:
+		result := make(map[string]interface{})
+		for k, raw := range v.(map[string]interface{}) {
+			var parsed interface{}
+			if err := json.Unmarshal([]byte(raw.(string)), &parsed); err != nil {
+				return nil, fmt.Errorf("%s.%s is not valid JSON: %s", f.attr, k, err)
+			}
+			result[k] = parsed
+		}
+		return result, nil
+	case settingLDAPSearch:
+		return expandLDAPSearch(v.([]interface{})), nil
+	}
+	return v, nil
+}
+
+// flattenSetting converts a setting value into the attribute value, old is
+// the current attribute value kept for equivalent JSON and encrypted values
+func flattenSetting(f settingField, old, value interface{}) interface{} {
+	if f.sensitive && value == encryptedSetting {
+		return old
+	}
+	switch f.kind {
+	case settingBool:
+		b, _ := value.(bool)
+		return b
+	case settingStringList:
+		var result []interface{}
+		if list, ok := value.([]interface{}); ok {
+			for _, i := range list {
+				result = append(result, fmt.Sprintf("%v", i))
+			}
+		}
+		return result
+	case settingStringMap:
+		result := make(map[string]interface{})
+		if m, ok := value.(map[string]interface{}); ok {
+			for k, v := range m {
+				result[k] = fmt.Sprintf("%v", v)
+			}
+		}
+		return result
+	case settingJSON:
+		if value == nil {
+			return ""
+		}
+		if s, ok := old.(string); ok && s != "" && settingJSONEqual(s, value) {
+			return s
+		}
+		b, _ := json.Marshal(value)
+		return string(b)
+	case settingJSONMap:
+		oldMap, _ := old.(map[string]interface{})
+		result := make(map[string]interface{})
+		if m, ok := value.(map[string]interface{}); ok {
+			for k, v := range m {
+				result[k] = flattenSetting(settingField{kind: settingJSON}, oldMap[k], v)
+			}
+		}
+		return result
+	case settingLDAPSearch:
+		return flattenLDAPSearch(value)
+	}
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// isSettingConfigured tells whether the attribute is set, in Read it tells
+// whether the attribute is managed
+func isSettingConfigured(d *schema.ResourceData, f settingField) bool {
+	if f.kind == settingBool {
+		_, ok := d.GetOkExists(f.attr)
+		return ok
+	}
+	_, ok := d.GetOk(f.attr)
+	return ok
+}
+
+func createTypedSettings(d *schema.ResourceData, awx *AWXClient, category, prefix string, fields []settingField) error {
+	values := make(map[string]interface{})
+	for _, f := range fields {
+		if !isSettingConfigured(d, f) {
+			continue
+		}
+		value, err := expandSetting(f, d.Get(f.attr))
+		if err != nil {
+			return err
+		}
+		values[f.settingKey(prefix)] = value
+	}
+	return updateSettings(awx, category, values, nil)
+}
+
+func updateTypedSettings(d *schema.ResourceData, awx *AWXClient, category, prefix string, fields []settingField) error {
+	values := make(map[string]interface{})
+	var removed []string
+	for _, f := range fields {
+		if !d.HasChange(f.attr) {
+			continue
+		}
+		if f.readOnly {
+			continue
+		}
+		// Attributes removed from the configuration are reset to their default
+		if !isSettingConfigured(d, f) {
+			removed = append(removed, f.settingKey(prefix))
+			continue
+		}
+		value, err := expandSetting(f, d.Get(f.attr))
+		if err != nil {
+			return err
+		}
+		values[f.settingKey(prefix)] = value
+	}
+	return updateSettings(awx, category, values, removed)
+}
+
+func readTypedSettings(d *schema.ResourceData, awx *AWXClient, category, prefix string, fields []settingField) error {
+	current, err := getSettings(awx, category)
+	if err != nil {
+		if isAPINotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	for _, f := range fields {
+		// Attributes left unset are not managed, their settings aren't read
+		if !f.readOnly && !isSettingConfigured(d, f) {
+			continue
+		}
+		value, ok := current[f.settingKey(prefix)]
+		if !ok {
+			continue
+		}
+		if err := d.Set(f.attr, flattenSetting(f, d.Get(f.attr), value)); err != nil {
+			return fmt.Errorf("Failed to set %s: %s", f.attr, err)
+		}
+	}
+	return nil
+}
+
+// importTypedSettings sets the attributes whose settings differ from their
+// default, so an imported resource manages what was changed in AWX. Secret
+// values can't be read back and are left unset.
+func importTypedSettings(d *schema.ResourceData, awx *AWXClient, category, prefix string, fields []settingField) error {
+	current, err := getSettings(awx, category)
+	if err != nil {
+		return err
+	}
+	meta, err := getSettingsMetadata(awx, category)
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		if f.readOnly || f.sensitive {
+			continue
+		}
+		key := f.settingKey(prefix)
+		value, ok := current[key]
+		if !ok || settingValueEqual(value, meta[key].Default) {
+			continue
+		}
+		if err := d.Set(f.attr, flattenSetting(f, nil, value)); err != nil {
+			return fmt.Errorf("Failed to set %s: %s", f.attr, err)
+		}
+	}
+	return nil
+}
+
+// settingValueEqual compares two decoded setting values, numbers may be
+// decoded differently
+func settingValueEqual(a, b interface{}) bool {
+	encoded, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	return settingJSONEqual(string(encoded), b)
+}
+
+func deleteTypedSettings(d *schema.ResourceData, awx *AWXClient, category, prefix string, fields []settingField) error {
+	var keys []string
+	for _, f := range fields {
+		if !f.readOnly {
+			keys = append(keys, f.settingKey(prefix))
+		}
+	}
+	if err := updateSettings(awx, category, nil, keys); err != nil && !isAPINotFound(err) {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+// resourceTypedSettingsObject builds the resource of a whole settings
+// category, the ID is the category
+func resourceTypedSettingsObject(category string, fields []settingField) *schema.Resource {
+	return &schema.Resource{
+		Create: func(d *schema.ResourceData, m interface{}) error {
+			if err := createTypedSettings(d, m.(*AWXClient), category, "", fields); err != nil {
+				return err
+			}
+			d.SetId(category)
+			return readTypedSettings(d, m.(*AWXClient), category, "", fields)
+		},
+		Read: func(d *schema.ResourceData, m interface{}) error {
+			return readTypedSettings(d, m.(*AWXClient), category, "", fields)
+		},
+		Update: func(d *schema.ResourceData, m interface{}) error {
+			if err := updateTypedSettings(d, m.(*AWXClient), category, "", fields); err != nil {
+				return err
+			}
+			return readTypedSettings(d, m.(*AWXClient), category, "", fields)
+		},
+		Delete: func(d *schema.ResourceData, m interface{}) error {
+			return deleteTypedSettings(d, m.(*AWXClient), category, "", fields)
+		},
+		Schema: typedSettingsSchema(fields),
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				if err := importTypedSettings(d, m.(*AWXClient), category, "", fields); err != nil {
+					return nil, err
+				}
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+	}
+}